@@ -15,6 +15,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"net/http"
@@ -26,11 +27,13 @@ import (
 	"github.com/blinklabs-io/tx-submit-api/internal/config"
 	"github.com/blinklabs-io/tx-submit-api/internal/logging"
 	"github.com/blinklabs-io/tx-submit-api/internal/version"
+	"github.com/blinklabs-io/tx-submit-api/submit"
 	"go.uber.org/automaxprocs/maxprocs"
 )
 
 var cmdlineFlags struct {
 	configFile string
+	verifyTx   string
 }
 
 func logPrintf(format string, v ...any) {
@@ -44,8 +47,18 @@ func main() {
 		"",
 		"path to config file to load",
 	)
+	flag.StringVar(
+		&cmdlineFlags.verifyTx,
+		"verify-tx",
+		"",
+		"path to a CBOR, hex or TextEnvelope encoded transaction to verify the witnesses of, then exit",
+	)
 	flag.Parse()
 
+	if cmdlineFlags.verifyTx != "" {
+		os.Exit(verifyTx(cmdlineFlags.verifyTx))
+	}
+
 	// Load config
 	cfg, err := config.Load(cmdlineFlags.configFile)
 	if err != nil {
@@ -100,3 +113,34 @@ func main() {
 	// Wait forever
 	select {}
 }
+
+// verifyTx checks the vkey witnesses of the transaction in path, printing the
+// witness report as JSON, and returns the process exit code. Spent inputs are
+// not checked, as this does not connect to a node.
+func verifyTx(path string) int {
+	txRawBytes, err := os.ReadFile(path) // #nosec G304 -- transaction file strictly passed from CLI
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read transaction: %s\n", err)
+		return 1
+	}
+	txRawBytes, err = submit.ReadTxBytes(txRawBytes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read transaction: %s\n", err)
+		return 1
+	}
+	report, err := submit.VerifyWitnesses(txRawBytes, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to verify transaction: %s\n", err)
+		return 1
+	}
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode witness report: %s\n", err)
+		return 1
+	}
+	fmt.Println(string(out))
+	if !report.Valid() {
+		return 1
+	}
+	return 0
+}
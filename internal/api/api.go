@@ -28,12 +28,14 @@ import (
 	"net"
 	"net/http"
 	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros/ledger"
 	"github.com/blinklabs-io/gouroboros/protocol/localtxmonitor"
 	"github.com/blinklabs-io/gouroboros/protocol/localtxsubmission"
 	_ "github.com/blinklabs-io/tx-submit-api/docs" // docs is generated by Swag CLI
@@ -52,61 +54,93 @@ var staticFS embed.FS
 // submission. Cardano's protocol limit is ~16KB; 64KB gives ample overhead.
 const maxTxBodyBytes = 64 * 1024
 
+// dappAttribution attributes submitted transactions to dApps for metrics,
+// built from the configured rules on start. A nil value attributes nothing.
+var dappAttribution *submit.Attribution
+
 // nodeHealthState holds the latest result of the background readiness probe.
 type nodeHealthState struct {
 	mu        sync.RWMutex
 	healthy   bool
 	lastCheck time.Time
 	lastError error
+	// tip and lag are only meaningful when hasTip is set
+	hasTip bool
+	tip    submit.ChainTip
+	lag    time.Duration
 }
 
 var nodeHealth = &nodeHealthState{}
 
-// startNodeHealthPoller runs a background goroutine that periodically dials the
-// configured node transport (TCP or Unix socket) and updates nodeHealth. It runs
-// an initial check immediately so /healthz is never stale on first request.
-func startNodeHealthPoller(ctx context.Context, cfg *config.Config) {
+// probeNode reads the node chain tip from cache, and queries the node over
+// LocalStateQuery when the cached status is older than the cache TTL or
+// maxAge. The returned error is non-nil when the node can't be queried or its
+// tip is more than maxLag behind wall-clock time. A maxLag of 0 disables the
+// lag check.
+func probeNode(cache *nodeInfoCache, maxAge, maxLag time.Duration) (*submit.ChainTip, time.Duration, error) {
+	status, updated, err := cache.get()
+	if err == nil && time.Since(updated) > maxAge {
+		status, _, err = cache.refresh()
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	tipTime, err := status.TipTime()
+	if err != nil {
+		return &status.Tip, 0, fmt.Errorf("failure converting tip slot to time: %w", err)
+	}
+	// Clamp to zero so that minor clock skew doesn't report a negative lag.
+	lag := max(time.Since(tipTime), 0)
+	if maxLag > 0 && lag > maxLag {
+		return &status.Tip, lag, fmt.Errorf(
+			"node tip is %s behind wall-clock time (slot %d), node may still be syncing",
+			lag.Truncate(time.Second),
+			status.Tip.Slot,
+		)
+	}
+	return &status.Tip, lag, nil
+}
+
+// startNodeHealthPoller runs a background goroutine that periodically probes
+// the node with probeNode and updates nodeHealth. The probe shares the node
+// info cache, and only queries the node itself when the cached status is
+// older than the probe interval, so /healthz reflects a node status at most
+// two probe intervals old. It runs an initial check immediately so /healthz
+// is never stale on first request.
+func startNodeHealthPoller(ctx context.Context, cfg *config.Config, cache *nodeInfoCache) {
 	logger := logging.GetLogger()
+	maxLag := time.Duration(cfg.Node.MaxTipLag) * time.Second // #nosec G115
+	interval := cfg.Node.HealthCheckInterval
+	if interval <= 0 {
+		interval = 30
+	}
+	probeInterval := time.Duration(interval) * time.Second // #nosec G115
 	probe := func() {
-		timeout := time.Duration(cfg.Node.Timeout) * time.Second // #nosec G115
-		var err error
-		if cfg.Node.Address != "" && cfg.Node.Port > 0 {
-			addr := net.JoinHostPort(cfg.Node.Address, strconv.FormatUint(uint64(cfg.Node.Port), 10))
-			var conn net.Conn
-			conn, err = net.DialTimeout("tcp", addr, timeout)
-			if err == nil {
-				_ = conn.Close()
-			}
-		} else {
-			var conn net.Conn
-			conn, err = net.DialTimeout("unix", cfg.Node.SocketPath, timeout)
-			if err == nil {
-				_ = conn.Close()
-			}
-		}
+		tip, lag, err := probeNode(cache, probeInterval, maxLag)
 
 		nodeHealth.mu.Lock()
 		prev := nodeHealth.healthy
 		nodeHealth.healthy = err == nil
 		nodeHealth.lastCheck = time.Now()
 		nodeHealth.lastError = err
+		nodeHealth.hasTip = tip != nil
+		if tip != nil {
+			nodeHealth.tip = *tip
+			nodeHealth.lag = lag
+		}
 		nodeHealth.mu.Unlock()
 
 		// Log only on state transitions to avoid noise.
 		if err == nil && !prev {
-			logger.Info("node became reachable")
+			logger.Info("node became ready")
 		} else if err != nil && prev {
-			logger.Error("node became unreachable", "err", err)
+			logger.Error("node became unready", "err", err)
 		}
 	}
 
 	go func() {
 		probe()
-		interval := cfg.Node.HealthCheckInterval
-		if interval <= 0 {
-			interval = 30
-		}
-		ticker := time.NewTicker(time.Duration(interval) * time.Second) // #nosec G115
+		ticker := time.NewTicker(probeInterval)
 		defer ticker.Stop()
 		for {
 			select {
@@ -227,8 +261,51 @@ func newMux(fsys fs.FS, nh *nodeHealthState) *http.ServeMux {
 
 	// API routes
 	mux.HandleFunc("POST /api/submit/tx", handleSubmitTx)
+	mux.HandleFunc("POST /api/v1/submit/tx", handleSubmitTxV1)
 	mux.HandleFunc("GET /api/hastx/{tx_hash}", handleHasTx)
 
+	// Mempool inspection walks the node's mempool snapshot, so these share a
+	// concurrency limit to avoid tying up the node.
+	mempoolLimiter := newQueryLimiter(config.GetConfig().Mempool.MaxConcurrentQueries)
+	mux.HandleFunc("GET /api/mempool", func(w http.ResponseWriter, r *http.Request) {
+		handleMempool(w, r, mempoolLimiter)
+	})
+	mux.HandleFunc("GET /api/mempool/txs", func(w http.ResponseWriter, r *http.Request) {
+		handleMempoolTxs(w, r, mempoolLimiter)
+	})
+	mux.HandleFunc("GET /api/mempool/txs/{tx_hash}", func(w http.ResponseWriter, r *http.Request) {
+		handleMempoolTx(w, r, mempoolLimiter)
+	})
+
+	nodeInfoCache := getNodeInfoCache(config.GetConfig())
+	mux.HandleFunc("GET /api/node/info", func(w http.ResponseWriter, r *http.Request) {
+		handleNodeInfo(w, r, nodeInfoCache)
+	})
+	mux.HandleFunc("GET /api/time/now", func(w http.ResponseWriter, r *http.Request) {
+		handleTimeNow(w, r, nodeInfoCache)
+	})
+	mux.HandleFunc("GET /api/time/slot/{slot}", func(w http.ResponseWriter, r *http.Request) {
+		handleSlotTime(w, r, nodeInfoCache)
+	})
+
+	protocolParamsCache := getProtocolParamsCache(config.GetConfig())
+	mux.HandleFunc("GET /api/protocol-parameters", func(w http.ResponseWriter, r *http.Request) {
+		handleProtocolParams(w, r, protocolParamsCache)
+	})
+	mux.HandleFunc("POST /api/validate/tx", func(w http.ResponseWriter, r *http.Request) {
+		handleValidateTx(w, r, protocolParamsCache)
+	})
+	mux.HandleFunc("POST /api/estimate/fee", func(w http.ResponseWriter, r *http.Request) {
+		handleEstimateFee(w, r, protocolParamsCache)
+	})
+	mux.HandleFunc("POST /api/decode/tx", handleDecodeTx)
+	mux.HandleFunc("POST /api/txhash", func(w http.ResponseWriter, r *http.Request) {
+		handleTxHash(w, r, protocolParamsCache)
+	})
+	mux.HandleFunc("POST /api/evaluate/tx", func(w http.ResponseWriter, r *http.Request) {
+		handleEvaluateTx(w, r, protocolParamsCache, nodeInfoCache)
+	})
+
 	return mux
 }
 
@@ -244,6 +321,36 @@ func writeJSON(w http.ResponseWriter, status int, data any) {
 	_, _ = buf.WriteTo(w)
 }
 
+// readCborBody reads an application/cbor transaction request body, capped at
+// maxTxBodyBytes. It writes an error response and returns false if the body
+// cannot be used.
+func readCborBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	return readBody(w, r, "application/cbor")
+}
+
+// readBody is readCborBody for a request body of any of mediaTypes.
+func readBody(w http.ResponseWriter, r *http.Request, mediaTypes ...string) ([]byte, bool) {
+	logger := logging.GetLogger()
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if !slices.Contains(mediaTypes, mediaType) {
+		writeJSON(w, http.StatusUnsupportedMediaType,
+			"invalid request body, should be "+strings.Join(mediaTypes, " or "))
+		return nil, false
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, "request body too large")
+		} else {
+			logger.Error("failed to read request body", "err", err)
+			writeJSON(w, http.StatusInternalServerError, "failed to read request body")
+		}
+		return nil, false
+	}
+	return body, true
+}
+
 // @title			tx-submit-api
 // @version		v0
 // @description	Cardano Transaction Submit API
@@ -277,7 +384,35 @@ func Start(cfg *config.Config) error {
 		return err
 	}
 
-	startNodeHealthPoller(context.Background(), cfg)
+	if cfg.Node.ShelleyGenesisFile != "" {
+		clock, err := submit.SlotClockFromShelleyGenesis(cfg.Node.ShelleyGenesisFile)
+		if err != nil {
+			return err
+		}
+		genesisClock = clock
+	}
+	attribution, err := submit.NewAttribution(cfg.Metrics.Attribution)
+	if err != nil {
+		return fmt.Errorf("invalid dApp attribution rules: %w", err)
+	}
+	dappAttribution = attribution
+	// Histogram buckets must be set before the pollers below dial the node
+	if err := metrics.ConfigureHistograms(
+		cfg.Metrics.DurationBuckets,
+		cfg.Metrics.TxSizeBuckets,
+		cfg.Metrics.TxFeeBuckets,
+	); err != nil {
+		return fmt.Errorf("invalid metrics buckets: %w", err)
+	}
+	submit.ObserveNodeCalls(recordNodeCall)
+
+	startNodeHealthPoller(context.Background(), cfg, getNodeInfoCache(cfg))
+	startMempoolCollector(context.Background(), cfg, mempoolStatus, pendingTxs)
+	startNodeInfoRefresher(context.Background(), getNodeInfoCache(cfg))
+	if err := admissionPolicy.load(cfg); err != nil {
+		return fmt.Errorf("failed to load admission policy: %w", err)
+	}
+	startPolicyReloader(context.Background(), cfg, admissionPolicy)
 	mux := newMux(fsys, nodeHealth)
 
 	skipPaths := []string{}
@@ -333,15 +468,38 @@ func handleLiveness(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+type readinessResponse struct {
+	Status     string   `json:"status"`
+	TipSlot    *uint64  `json:"tipSlot,omitempty"`
+	BlockNo    *uint64  `json:"blockNo,omitempty"`
+	LagSeconds *float64 `json:"lagSeconds,omitempty"`
+	LastCheck  string   `json:"lastCheck,omitempty"`
+	LastError  string   `json:"lastError,omitempty"`
+}
+
 func handleReadiness(w http.ResponseWriter, _ *http.Request, nh *nodeHealthState) {
 	nh.mu.RLock()
+	resp := readinessResponse{Status: "unavailable"}
+	if nh.healthy {
+		resp.Status = "ok"
+	}
+	if nh.hasTip {
+		tipSlot, blockNo, lag := nh.tip.Slot, nh.tip.BlockNo, nh.lag.Seconds()
+		resp.TipSlot, resp.BlockNo, resp.LagSeconds = &tipSlot, &blockNo, &lag
+	}
+	if !nh.lastCheck.IsZero() {
+		resp.LastCheck = nh.lastCheck.UTC().Format(time.RFC3339)
+	}
+	if nh.lastError != nil {
+		resp.LastError = nh.lastError.Error()
+	}
 	healthy := nh.healthy
 	nh.mu.RUnlock()
 
 	if healthy {
-		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		writeJSON(w, http.StatusOK, resp)
 	} else {
-		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable"})
+		writeJSON(w, http.StatusServiceUnavailable, resp)
 	}
 }
 
@@ -396,7 +554,16 @@ func handleHasTx(w http.ResponseWriter, r *http.Request) {
 	}
 	defer oConn.Close()
 
+	hasTxStart := time.Now()
 	hasTx, err := oConn.LocalTxMonitor().Client.HasTx(txHashBytes)
+	hasTxResult := "found"
+	switch {
+	case err != nil:
+		hasTxResult = "error"
+	case !hasTx:
+		hasTxResult = "not_found"
+	}
+	metrics.ObserveHasTx(hasTxResult, nodeEndpoint(cfg), time.Since(hasTxStart))
 	if err != nil {
 		logger.Error("failure getting transaction", "err", err)
 		writeJSON(w, http.StatusInternalServerError,
@@ -421,14 +588,69 @@ func handleHasTx(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor)
 //	@Success		202				{object}	string	"Transaction accepted into node mempool"
-//	@Failure		400				{object}	string	"Bad Request"
+//	@Failure		400				{object}	invalidWitnessesResponse	"Bad Request, with a report for invalid or missing witnesses or failing native scripts"
+//	@Failure		403				{object}	policyDeniedResponse	"Transaction refused by the admission policy"
+//	@Failure		409				{object}	missingInputsResponse	"Transaction inputs not found in the node UTxO set"
 //	@Failure		415				{object}	string	"Unsupported Media Type"
 //	@Failure		500				{object}	string	"Server Error"
+//	@Failure		503				{object}	string	"Node mempool near capacity"
 //	@Router			/api/submit/tx [post]
 func handleSubmitTx(w http.ResponseWriter, r *http.Request) {
+	submitTx(w, r, false)
+}
+
+// submitTxResponse is the response of /api/v1/submit/tx for an accepted
+// transaction.
+type submitTxResponse struct {
+	submit.TxInfo
+	// Node is the endpoint of the node that accepted the transaction
+	Node string `json:"node"`
+	// LatencyMs is the time taken by the node to accept the transaction, in
+	// milliseconds
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+// handleSubmitTxV1 godoc
+//
+//	@Summary		Submit Tx (v1)
+//	@Description	Submit an already serialized transaction to the network, like /api/submit/tx,
+//	@Description	but respond to an accepted transaction with a summary of its content, the
+//	@Description	node endpoint that accepted it and the submission latency.
+//	@Produce		json
+//	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor)
+//	@Success		202				{object}	submitTxResponse	"Transaction accepted into node mempool"
+//	@Failure		400				{object}	invalidWitnessesResponse	"Bad Request, with a report for invalid or missing witnesses or failing native scripts"
+//	@Failure		403				{object}	policyDeniedResponse	"Transaction refused by the admission policy"
+//	@Failure		409				{object}	missingInputsResponse	"Transaction inputs not found in the node UTxO set"
+//	@Failure		415				{object}	string	"Unsupported Media Type"
+//	@Failure		500				{object}	string	"Server Error"
+//	@Failure		503				{object}	string	"Node mempool near capacity"
+//	@Router			/api/v1/submit/tx [post]
+func handleSubmitTxV1(w http.ResponseWriter, r *http.Request) {
+	submitTx(w, r, true)
+}
+
+// nodeEndpoint returns the endpoint of the node transactions are submitted
+// to, as "tcp://host:port" or "unix://path".
+func nodeEndpoint(cfg *config.Config) string {
+	return submit.NodeEndpoint(cfg.Node.Address, cfg.Node.Port, cfg.Node.SocketPath)
+}
+
+// submitTx submits the transaction in the request body. An accepted
+// transaction is answered with its hash, or with a submitTxResponse if
+// summary is set.
+func submitTx(w http.ResponseWriter, r *http.Request, summary bool) {
 	cfg := config.GetConfig()
 	logger := logging.GetLogger()
 	clientIP := realClientIP(r, cfg.Api.TrustedProxies)
+	start := time.Now()
+	node := nodeEndpoint(cfg)
+	// dapp is set once the transaction is parsed
+	dapp := submit.AttributionNone
+	recordResult := func(result string) {
+		metrics.RecordTxRequest(result, dapp)
+		metrics.ObserveSubmitRequest(result, node, dapp, time.Since(start))
+	}
 
 	// Check our headers for content-type. Wrong content-type is rejected before
 	// reading the body, so no IP metric is recorded here.
@@ -440,6 +662,17 @@ func handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Refuse new submissions while the node mempool is nearly full, rather
+	// than tying up a node connection until SubmitTx times out.
+	if admit, ratio := mempoolStatus.admitTx(cfg); !admit {
+		logger.Warn("node mempool near capacity, refusing submission", "fillRatio", ratio, "ip", clientIP)
+		w.Header().Set("Retry-After", strconv.FormatUint(uint64(cfg.Mempool.MetricsInterval), 10))
+		writeJSON(w, http.StatusServiceUnavailable, "node mempool is near capacity, retry later")
+		metrics.IncTxSubmitFailCount()
+		recordResult("throttled")
+		return
+	}
+
 	// Read raw transaction bytes, capped at maxTxBodyBytes to prevent unbounded allocation.
 	txRawBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTxBodyBytes))
 	if err != nil {
@@ -452,15 +685,164 @@ func handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 			writeJSON(w, http.StatusInternalServerError, "failed to read request body")
 		}
 		metrics.IncTxSubmitFailCount()
-		metrics.RecordTxRequest("error")
+		recordResult("error")
 		return
 	}
 
 	// Parse tx content signals before submitting — best-effort, never blocks submission.
+	parseStart := time.Now()
 	txInfo, err := submit.ParseTxInfo(txRawBytes)
 	if err != nil {
+		metrics.ObserveTxParse("error", node, time.Since(parseStart))
 		logger.Warn("failed to parse tx content signals", "err", err, "ip", clientIP)
 		txInfo = nil
+	} else {
+		metrics.ObserveTxParse("ok", node, time.Since(parseStart))
+	}
+	dapp = dappAttribution.Attribute(txInfo)
+	if txInfo != nil && dapp != submit.AttributionNone {
+		txInfo.DApp = dapp
+	}
+
+	// Refuse transactions built for another network, which the node would
+	// otherwise reject with an obscure WrongNetwork error.
+	if cfg.Submit.CheckNetwork {
+		if msg := checkTxNetwork(cfg, txRawBytes); msg != "" {
+			logger.Info("refusing transaction for a different network", "ip", clientIP)
+			writeJSON(w, http.StatusBadRequest, msg)
+			metrics.IncTxSubmitFailCount()
+			recordResult("wrong_network")
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// Refuse expired transactions, which are the most common reason for the
+	// node to reject a submission, with a message saying when they expired.
+	if cfg.Submit.CheckValidity {
+		if msg := checkTxValidity(getNodeInfoCache(cfg), txRawBytes); msg != "" {
+			logger.Info("refusing transaction outside its validity interval", "ip", clientIP)
+			writeJSON(w, http.StatusBadRequest, msg)
+			metrics.IncTxSubmitFailCount()
+			recordResult("outside_validity")
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// The checks below share one UTxO query for all the inputs of the tx
+	resolve := submit.ResolveTxUTxOs(txRawBytes, func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return submit.ResolveUTxOs(nodeQueryConfig(cfg), inputs)
+	})
+
+	// Refuse transactions that violate the admission policy
+	if policy := admissionPolicy.get(); policy != nil && !policy.Empty() {
+		if status, body := checkAdmissionPolicy(policy, txRawBytes, resolve); status != 0 {
+			logger.Info("refusing transaction by admission policy", "status", status, "ip", clientIP)
+			writeJSON(w, status, body)
+			metrics.IncTxSubmitFailCount()
+			if status == http.StatusForbidden {
+				recordResult("policy_denied")
+			} else {
+				recordResult("error")
+			}
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// Fail fast on inputs that are already spent, rather than waiting for the
+	// node to reject the tx with BadInputsUTxO.
+	if cfg.Submit.CheckInputs {
+		if missing := checkTxInputs(txRawBytes, resolve, nodeMempoolLookup(cfg)); len(missing) > 0 {
+			logger.Info("refusing transaction with inputs missing from the node UTxO set",
+				"missing", len(missing), "ip", clientIP)
+			writeJSON(w, http.StatusConflict, missingInputsResponse{
+				Error:         "transaction inputs not found in the node UTxO set",
+				MissingInputs: missing,
+			})
+			metrics.IncTxSubmitFailCount()
+			recordResult("missing_inputs")
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// Refuse transactions with invalid signatures or missing witnesses, which
+	// are usually wallet bugs, naming the key hashes at fault.
+	if cfg.Submit.CheckWitnesses {
+		var resolveInputs submit.UTxOResolver
+		if cfg.Submit.CheckInputs {
+			resolveInputs = resolve
+		}
+		if report := checkTxWitnesses(txRawBytes, resolveInputs); report != nil {
+			logger.Info("refusing transaction with invalid or missing witnesses",
+				"invalid", len(report.InvalidSignatures), "missing", len(report.MissingWitnesses), "ip", clientIP)
+			writeJSON(w, http.StatusBadRequest, invalidWitnessesResponse{
+				Error:         report.String(),
+				WitnessReport: *report,
+			})
+			metrics.IncTxSubmitFailCount()
+			recordResult("invalid_witnesses")
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// Refuse transactions whose native scripts, such as time-locked minting
+	// policies, would fail validation.
+	if cfg.Submit.CheckNativeScripts {
+		if results := checkTxNativeScripts(txRawBytes); results != nil {
+			var failed []string
+			for _, result := range results {
+				if !result.Pass {
+					failed = append(failed, result.String())
+				}
+			}
+			logger.Info("refusing transaction with failing native scripts", "failed", len(failed), "ip", clientIP)
+			writeJSON(w, http.StatusBadRequest, nativeScriptsFailedResponse{
+				Error:         strings.Join(failed, "; "),
+				NativeScripts: results,
+			})
+			metrics.IncTxSubmitFailCount()
+			recordResult("native_script_failed")
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// Refuse transactions with bad collateral or outputs below the minimum
+	// lovelace, which are common mistakes when building Plutus transactions.
+	if cfg.Submit.CheckParams {
+		if report := checkTxParams(getProtocolParamsCache(cfg), txRawBytes, resolve); report != nil {
+			logger.Info("refusing transaction breaking protocol parameter limits",
+				"violations", len(report.Violations), "ip", clientIP)
+			writeJSON(w, http.StatusBadRequest, paramsViolationResponse{
+				Error:        report.String(),
+				ParamsReport: *report,
+			})
+			metrics.IncTxSubmitFailCount()
+			recordResult("params_violation")
+			recordTxContent(txInfo)
+			return
+		}
+	}
+
+	// Refuse transactions whose script data hash or auxiliary data hash does
+	// not match their contents, which the node would reject
+	if cfg.Submit.CheckHashes {
+		if report := checkTxHashes(getProtocolParamsCache(cfg), txRawBytes, resolve); report != nil {
+			logger.Info("refusing transaction with mismatched hashes",
+				"mismatches", len(report.Mismatches()), "ip", clientIP)
+			writeJSON(w, http.StatusBadRequest, hashMismatchResponse{
+				Error:        hashMismatchError(report),
+				TxHashReport: *report,
+			})
+			metrics.IncTxSubmitFailCount()
+			recordResult("hash_mismatch")
+			recordTxContent(txInfo)
+			return
+		}
 	}
 
 	// Send TX
@@ -473,7 +855,33 @@ func handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 		SocketPath:   cfg.Node.SocketPath,
 		Timeout:      cfg.Node.Timeout,
 	}
+	if cfg.Submit.CheckEra {
+		nodeCache := getNodeInfoCache(cfg)
+		submitConfig.CurrentEra = func(refresh bool) (ledger.Era, error) {
+			get := nodeCache.get
+			if refresh {
+				get = nodeCache.refresh
+			}
+			status, _, err := get()
+			if err != nil {
+				logger.Debug("skipping era check", "err", err)
+				return ledger.Era{}, err
+			}
+			return status.Era, nil
+		}
+	}
+	submitStart := time.Now()
 	txHash, err := submit.SubmitTx(submitConfig, txRawBytes)
+	latency := time.Since(submitStart)
+	var eraErr *submit.EraMismatchError
+	if errors.As(err, &eraErr) {
+		logger.Info("refusing transaction for another era", "txEra", eraErr.TxEra.Name, "nodeEra", eraErr.NodeEra.Name, "ip", clientIP)
+		writeJSON(w, http.StatusBadRequest, eraErr.Error())
+		metrics.IncTxSubmitFailCount()
+		recordResult("wrong_era")
+		recordTxContent(txInfo)
+		return
+	}
 	if err != nil {
 		var txRejectErr *localtxsubmission.TransactionRejectedError
 		isRejected := errors.As(err, &txRejectErr)
@@ -495,21 +903,32 @@ func handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 			result = "rejected"
 		}
 		metrics.IncTxSubmitFailCount()
-		metrics.RecordTxRequest(result)
-		if txInfo != nil {
-			metrics.RecordTxContent(txInfo.ScriptType, txInfo.HasMinting, txInfo.HasReferenceInputs)
-		}
+		recordResult(result)
+		recordTxContent(txInfo)
 		return
 	}
 
 	// Node confirmed the tx is in its mempool (AcceptTx received synchronously).
 	// Record success before responding so metrics always agree with the HTTP status.
 	metrics.IncTxSubmitCount()
-	metrics.RecordTxRequest("accepted")
-	if txInfo != nil {
-		metrics.RecordTxContent(txInfo.ScriptType, txInfo.HasMinting, txInfo.HasReferenceInputs)
+	recordResult("accepted")
+	recordTxContent(txInfo)
+	if cfg.Mempool.MetricsInterval > 0 {
+		pendingTxs.add(txHash)
+	}
+	if summary {
+		resp := submitTxResponse{
+			Node:      node,
+			LatencyMs: float64(latency.Microseconds()) / 1000,
+		}
+		if txInfo != nil {
+			resp.TxInfo = *txInfo
+		}
+		resp.Hash = txHash
+		writeJSON(w, http.StatusAccepted, resp)
+	} else {
+		writeJSON(w, http.StatusAccepted, txHash)
 	}
-	writeJSON(w, http.StatusAccepted, txHash)
 
 	// Drain errorChan in the background. Post-submission connection errors do not
 	// change the metric outcome — the tx is already in the mempool — but we log
@@ -525,6 +944,47 @@ func handleSubmitTx(w http.ResponseWriter, r *http.Request) {
 	}()
 }
 
+// recordTxContent records the content signals of a submitted transaction,
+// if it could be parsed.
+func recordTxContent(txInfo *submit.TxInfo) {
+	if txInfo == nil {
+		return
+	}
+	dapp := txInfo.DApp
+	if dapp == "" {
+		dapp = submit.AttributionNone
+	}
+	metrics.RecordTxContent(dapp, txInfo.ScriptType, txInfo.HasMinting, txInfo.HasReferenceInputs)
+	gov := txInfo.Governance
+	metrics.RecordTxGovernance(dapp, gov.Votes, gov.Proposals, gov.Certificates, gov.Donation)
+	staking := txInfo.Staking
+	metrics.RecordTxStaking(dapp, staking.Certificates, staking.Withdrawals, staking.WithdrawalAmount)
+	metrics.RecordTxSize(dapp, txInfo.Size, txInfo.Fee)
+}
+
+// recordNodeCall records the duration of a call to cardano-node made by the
+// submit package.
+func recordNodeCall(call, endpoint string, duration time.Duration, err error) {
+	switch call {
+	case submit.NodeCallDial:
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+		metrics.ObserveNodeDial(result, endpoint, duration)
+	case submit.NodeCallSubmitTx:
+		var txRejectErr *localtxsubmission.TransactionRejectedError
+		result := "accepted"
+		switch {
+		case errors.As(err, &txRejectErr):
+			result = "rejected"
+		case err != nil:
+			result = "error"
+		}
+		metrics.ObserveLocalTxSubmission(result, endpoint, duration)
+	}
+}
+
 // realClientIP extracts the client IP from the request. Forwarded headers
 // (X-Real-IP, X-Forwarded-For) are only trusted when the immediate peer
 // (r.RemoteAddr) is in the trustedProxies list; otherwise RemoteAddr is used
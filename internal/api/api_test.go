@@ -17,16 +17,20 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/blinklabs-io/tx-submit-api/internal/config"
 	"github.com/blinklabs-io/tx-submit-api/internal/logging"
 	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/blinklabs-io/tx-submit-api/submit"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
@@ -205,6 +209,46 @@ func TestReadiness_Unavailable(t *testing.T) {
 	}
 }
 
+func TestReadiness_ReportsTipAndError(t *testing.T) {
+	t.Parallel()
+	nh := &nodeHealthState{
+		healthy:   false,
+		hasTip:    true,
+		tip:       submit.ChainTip{Slot: 1000, BlockNo: 50},
+		lag:       10 * time.Minute,
+		lastCheck: time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC),
+		lastError: errors.New("node tip is 10m0s behind wall-clock time"),
+	}
+	rec := httptest.NewRecorder()
+	newTestMux(nh).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+	var body readinessResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse body: %s", err)
+	}
+	if body.Status != "unavailable" {
+		t.Errorf("expected status=unavailable, got %q", body.Status)
+	}
+	if body.TipSlot == nil || *body.TipSlot != 1000 {
+		t.Errorf("expected tipSlot=1000, got %v", body.TipSlot)
+	}
+	if body.BlockNo == nil || *body.BlockNo != 50 {
+		t.Errorf("expected blockNo=50, got %v", body.BlockNo)
+	}
+	if body.LagSeconds == nil || *body.LagSeconds != 600 {
+		t.Errorf("expected lagSeconds=600, got %v", body.LagSeconds)
+	}
+	if body.LastCheck != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected lastCheck %q", body.LastCheck)
+	}
+	if !strings.Contains(body.LastError, "behind wall-clock time") {
+		t.Errorf("unexpected lastError %q", body.LastError)
+	}
+}
+
 // --- submit tx ---
 
 func TestSubmitTx_ContentType(t *testing.T) {
@@ -256,6 +300,52 @@ func TestSubmitTx_InvalidTxBytes(t *testing.T) {
 	}
 }
 
+func TestSubmitTxV1_InvalidTxBytes(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/submit/tx", strings.NewReader("not-valid-cbor"))
+	req.Header.Set("Content-Type", "application/cbor")
+	newTestMux(&nodeHealthState{}).ServeHTTP(rec, req)
+
+	// Errors are reported the same way as on /api/submit/tx
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestNodeEndpoint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		node config.NodeConfig
+		want string
+	}{
+		{
+			name: "tcp",
+			node: config.NodeConfig{Address: "node.example.com", Port: 3001, SocketPath: "/ipc/node.socket"},
+			want: "tcp://node.example.com:3001",
+		},
+		{
+			name: "ipv6",
+			node: config.NodeConfig{Address: "::1", Port: 3001},
+			want: "tcp://[::1]:3001",
+		},
+		{
+			name: "socket",
+			node: config.NodeConfig{SocketPath: "/ipc/node.socket"},
+			want: "unix:///ipc/node.socket",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := nodeEndpoint(&config.Config{Node: tt.node}); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
 // --- has tx ---
 
 func TestHasTx_NoNode(t *testing.T) {
@@ -326,7 +416,7 @@ func TestSubmitTx_RequestsTotal_InvalidCBOR(t *testing.T) {
 	// Not parallel: reads counter value which is package-global state.
 	// httptest.NewRequest sets RemoteAddr = "192.0.2.1:1234".
 	const clientIP = "192.0.2.1"
-	before := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error"))
+	before := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error", "none"))
 
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "/api/submit/tx", strings.NewReader("not-valid-cbor"))
@@ -336,7 +426,7 @@ func TestSubmitTx_RequestsTotal_InvalidCBOR(t *testing.T) {
 	if rec.Code != http.StatusBadRequest {
 		t.Fatalf("expected 400, got %d", rec.Code)
 	}
-	after := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error"))
+	after := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error", "none"))
 	if after-before != 1 {
 		t.Errorf("requests_total{ip=%s,result=error}: expected increment of 1, got %f", clientIP, after-before)
 	}
@@ -345,7 +435,7 @@ func TestSubmitTx_RequestsTotal_InvalidCBOR(t *testing.T) {
 func TestSubmitTx_RequestsTotal_NoIncrementOnBadContentType(t *testing.T) {
 	// Not parallel: reads counter value which is package-global state.
 	const clientIP = "192.0.2.1"
-	before := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error"))
+	before := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error", "none"))
 
 	rec := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "/api/submit/tx", strings.NewReader("data"))
@@ -355,7 +445,7 @@ func TestSubmitTx_RequestsTotal_NoIncrementOnBadContentType(t *testing.T) {
 	if rec.Code != http.StatusUnsupportedMediaType {
 		t.Fatalf("expected 415, got %d", rec.Code)
 	}
-	after := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error"))
+	after := testutil.ToFloat64(metrics.TxSubmitRequestsTotal().WithLabelValues("error", "none"))
 	if after != before {
 		t.Errorf("requests_total should not increment on content-type rejection, got increment of %f", after-before)
 	}
@@ -377,5 +467,63 @@ func TestStartNodeHealthPoller_ZeroInterval(t *testing.T) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	startNodeHealthPoller(ctx, cfg)
+	cache := newNodeInfoCache(0, func() (*submit.NodeStatus, error) {
+		return nil, errors.New("dial failed")
+	})
+	startNodeHealthPoller(ctx, cfg, cache)
+}
+
+func TestProbeNode(t *testing.T) {
+	t.Parallel()
+	status := testNodeStatus(t)
+	var calls atomic.Int32
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		calls.Add(1)
+		return status, nil
+	})
+	tip, lag, err := probeNode(cache, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tip.Slot != status.Tip.Slot || lag <= 0 {
+		t.Errorf("unexpected tip %d with lag %s", tip.Slot, lag)
+	}
+	// The status test tip is well behind wall-clock time
+	if _, _, err := probeNode(cache, time.Minute, 300*time.Second); err == nil {
+		t.Error("expected lagging tip to fail the probe")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected probes to share one cached node query, got %d", n)
+	}
+	noNode := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return nil, errors.New("dial failed")
+	})
+	if _, _, err := probeNode(noNode, time.Minute, 0); err == nil {
+		t.Error("expected probe to fail without a node")
+	}
+}
+
+func TestProbeNode_StaleCache(t *testing.T) {
+	t.Parallel()
+	status := testNodeStatus(t)
+	var down atomic.Bool
+	cache := newNodeInfoCache(time.Hour, func() (*submit.NodeStatus, error) {
+		if down.Load() {
+			return nil, errors.New("dial failed")
+		}
+		return status, nil
+	})
+	if _, _, err := probeNode(cache, time.Hour, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	down.Store(true)
+	// The cached status is recent enough for the probe interval
+	if _, _, err := probeNode(cache, time.Hour, 0); err != nil {
+		t.Errorf("expected the cached status to be used, got %s", err)
+	}
+	// The cached status is older than the probe interval, so the node is
+	// queried again
+	if _, _, err := probeNode(cache, 0, 0); err == nil {
+		t.Error("expected probe to fail once the node is gone")
+	}
 }
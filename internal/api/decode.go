@@ -0,0 +1,53 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// handleDecodeTx godoc
+//
+//	@Summary		Decode Tx
+//	@Description	Decode a transaction without submitting it, and return a JSON rendering of its body, witnesses and
+//	@Description	metadata. The transaction can be sent as CBOR, as hex-encoded CBOR or as a cardano-cli TextEnvelope.
+//	@Accept			application/cbor
+//	@Accept			text/plain
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor, text/plain, application/json)
+//	@Success		200				{object}	submit.DecodedTx	"Ok"
+//	@Failure		400				{object}	string				"Bad Request"
+//	@Failure		415				{object}	string				"Unsupported Media Type"
+//	@Router			/api/decode/tx [post]
+func handleDecodeTx(w http.ResponseWriter, r *http.Request) {
+	body, ok := readBody(w, r, "application/cbor", "text/plain", "application/json")
+	if !ok {
+		return
+	}
+	txRawBytes, err := submit.ReadTxBytes(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	decoded, err := submit.DecodeTx(txRawBytes)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, decoded)
+}
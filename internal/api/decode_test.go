@@ -0,0 +1,102 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+func TestHandleDecodeTx(t *testing.T) {
+	t.Parallel()
+	txHex := minimalConwayTxHex
+	txBytes, err := hex.DecodeString(txHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %v", err)
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{
+			name:        "cbor",
+			contentType: "application/cbor",
+			body:        string(txBytes),
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "hex",
+			contentType: "text/plain",
+			body:        txHex,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "text envelope",
+			contentType: "application/json",
+			body:        `{"type": "Tx ConwayEra", "description": "", "cborHex": "` + txHex + `"}`,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "invalid CBOR",
+			contentType: "application/cbor",
+			body:        "not-valid-cbor",
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "invalid text envelope",
+			contentType: "application/json",
+			body:        `{"type": "Tx ConwayEra"}`,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "wrong content type",
+			contentType: "application/octet-stream",
+			body:        txHex,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodPost, "/api/decode/tx", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			newTestMux(&nodeHealthState{}).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var decoded submit.DecodedTx
+			if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+				t.Fatalf("decode response: %s", err)
+			}
+			if decoded.Era != "Conway" || len(decoded.Body.Outputs) != 1 || decoded.Body.Fee.Uint64() != 100_000 {
+				t.Errorf("unexpected decoded transaction %+v", decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type evaluateResponse struct {
+	// Success is set when the script of every redeemer succeeded.
+	Success   bool                        `json:"success"`
+	Redeemers []submit.RedeemerEvaluation `json:"redeemers"`
+}
+
+// handleEvaluateTx godoc
+//
+//	@Summary		Evaluate Tx
+//	@Description	Run the Plutus script of each redeemer of a transaction without submitting it.
+//	@Description	The spent and reference inputs are resolved from the node to build the script context, and scripts run
+//	@Description	against the node's cost models within the transaction limit. Returns the consumed execution units, result
+//	@Description	and traces of each redeemer, and whether the budget declared by the redeemer is enough.
+//	@Accept			application/cbor
+//	@Produce		json
+//	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor)
+//	@Success		200				{object}	evaluateResponse	"Ok"
+//	@Failure		400				{object}	string				"Bad Request"
+//	@Failure		415				{object}	string				"Unsupported Media Type"
+//	@Failure		500				{object}	string				"Server Error"
+//	@Router			/api/evaluate/tx [post]
+func handleEvaluateTx(
+	w http.ResponseWriter,
+	r *http.Request,
+	pparamsCache *protocolParamsCache,
+	nodeCache *nodeInfoCache,
+) {
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	txRawBytes, ok := readCborBody(w, r)
+	if !ok {
+		return
+	}
+
+	pparams, err := pparamsCache.get()
+	if err != nil {
+		logger.Error("failure getting protocol parameters", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	status, _, err := nodeCache.get()
+	if err != nil {
+		logger.Error("failure getting node info", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	var nodeErr error
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		utxos, err := submit.ResolveUTxOs(nodeQueryConfig(cfg), inputs)
+		nodeErr = err
+		return utxos, err
+	}
+	results, err := submit.EvaluateTx(txRawBytes, pparams, status.Clock, resolve)
+	if err != nil {
+		if nodeErr != nil {
+			logger.Error("failure resolving transaction inputs", "err", err)
+			writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp := evaluateResponse{
+		Success:   true,
+		Redeemers: make([]submit.RedeemerEvaluation, 0, len(results)),
+	}
+	for _, result := range results {
+		resp.Success = resp.Success && result.Success
+		resp.Redeemers = append(resp.Redeemers, result)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+func TestHandleEvaluateTx_BadRequest(t *testing.T) {
+	t.Parallel()
+	pparamsCache := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return testProtocolParams(), nil
+		},
+		epochEnd: func(uint64) (time.Time, error) {
+			return time.Now().Add(time.Hour), nil
+		},
+	}
+	status := testNodeStatus(t)
+	nodeCache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return status, nil
+	})
+	unreachable := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return nil, errors.New("connection refused")
+	})
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		nodeCache   *nodeInfoCache
+		wantCode    int
+	}{
+		{
+			name:        "wrong content type",
+			contentType: "application/json",
+			body:        "{}",
+			nodeCache:   nodeCache,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "invalid CBOR",
+			contentType: "application/cbor",
+			body:        "not-valid-cbor",
+			nodeCache:   nodeCache,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "node unreachable",
+			contentType: "application/cbor",
+			body:        "not-valid-cbor",
+			nodeCache:   unreachable,
+			wantCode:    http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodPost, "/api/evaluate/tx", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handleEvaluateTx(rec, req, pparamsCache, tt.nodeCache)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
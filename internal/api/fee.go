@@ -0,0 +1,84 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// handleEstimateFee godoc
+//
+//	@Summary		Estimate Fee
+//	@Description	Compute the minimum fee of an unsigned or partially signed transaction using the node's protocol parameters.
+//	@Description	The minimum fee is the linear size fee for the transaction once it carries the expected number of vkey witnesses,
+//	@Description	plus the execution unit fee of its redeemers and the tiered reference script fee.
+//	@Description	Returns the declared fee, the minimum fee and the shortfall or surplus.
+//	@Accept			application/cbor
+//	@Produce		json
+//	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor)
+//	@Param			witnesses		query		int		false	"Expected number of vkey witnesses once signed (default: those present)"
+//	@Success		200				{object}	submit.FeeEstimate	"Ok"
+//	@Failure		400				{object}	string				"Bad Request"
+//	@Failure		415				{object}	string				"Unsupported Media Type"
+//	@Failure		500				{object}	string				"Server Error"
+//	@Router			/api/estimate/fee [post]
+func handleEstimateFee(w http.ResponseWriter, r *http.Request, pparamsCache *protocolParamsCache) {
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	var witnesses int
+	if v := r.URL.Query().Get("witnesses"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeJSON(w, http.StatusBadRequest, "invalid witnesses, must be a non-negative integer")
+			return
+		}
+		witnesses = n
+	}
+	txRawBytes, ok := readCborBody(w, r)
+	if !ok {
+		return
+	}
+
+	pparams, err := pparamsCache.get()
+	if err != nil {
+		logger.Error("failure getting protocol parameters", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	var nodeErr error
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		utxos, err := submit.ResolveUTxOs(nodeQueryConfig(cfg), inputs)
+		nodeErr = err
+		return utxos, err
+	}
+	estimate, err := submit.EstimateFee(txRawBytes, witnesses, pparams, resolve)
+	if err != nil {
+		if nodeErr != nil {
+			logger.Error("failure resolving transaction inputs", "err", err)
+			writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, estimate)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+func TestHandleEstimateFee_BadRequest(t *testing.T) {
+	t.Parallel()
+	cache := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return testProtocolParams(), nil
+		},
+		epochEnd: func(uint64) (time.Time, error) {
+			return time.Now().Add(time.Hour), nil
+		},
+	}
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{
+			name:        "wrong content type",
+			contentType: "application/json",
+			body:        "{}",
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "negative witnesses",
+			query:       "?witnesses=-1",
+			contentType: "application/cbor",
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "invalid CBOR",
+			query:       "?witnesses=1",
+			contentType: "application/cbor",
+			body:        "not-valid-cbor",
+			wantCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodPost, "/api/estimate/fee"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handleEstimateFee(rec, req, cache)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
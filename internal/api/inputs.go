@@ -0,0 +1,120 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type missingInputsResponse struct {
+	Error         string                `json:"error"`
+	MissingInputs []submit.MissingInput `json:"missingInputs"`
+}
+
+// mempoolTxLookup reports which of a set of transaction hashes are in the
+// node mempool.
+type mempoolTxLookup func(txHashes []string) (map[string]bool, error)
+
+// nodeMempoolLookup returns a mempoolTxLookup that trusts transactions
+// accepted through this API that are still pending, and asks the node about
+// the others over LocalTxMonitor.
+func nodeMempoolLookup(cfg *config.Config) mempoolTxLookup {
+	return func(txHashes []string) (map[string]bool, error) {
+		ret := make(map[string]bool, len(txHashes))
+		var query [][]byte
+		for _, txHash := range txHashes {
+			if pendingTxs.has(txHash) {
+				ret[txHash] = true
+				continue
+			}
+			b, err := hex.DecodeString(txHash)
+			if err != nil {
+				continue
+			}
+			query = append(query, b)
+		}
+		if len(query) == 0 {
+			return ret, nil
+		}
+		_, present, err := submit.GetMempoolStatus(nodeQueryConfig(cfg), query)
+		if err != nil {
+			return nil, err
+		}
+		for i, ok := range present {
+			ret[hex.EncodeToString(query[i])] = ok
+		}
+		return ret, nil
+	}
+}
+
+// checkTxInputs returns the inputs of a transaction that are missing from the
+// node UTxO set. Inputs produced by a transaction still in the node mempool
+// are not in the UTxO set yet, but the node accepts transactions chaining on
+// them, so they are not reported. The check fails open: if the transaction
+// cannot be decoded or the node cannot be queried, no inputs are reported
+// missing and the node remains the final judge on submission.
+func checkTxInputs(txRawBytes []byte, resolve submit.UTxOResolver, inMempool mempoolTxLookup) []submit.MissingInput {
+	logger := logging.GetLogger()
+	missing, err := submit.FindMissingInputs(txRawBytes, resolve)
+	if err == nil && len(missing) > 0 {
+		missing, err = dropMempoolInputs(missing, inMempool)
+	}
+	if err != nil {
+		logger.Warn("failed to check transaction inputs", "err", err)
+		metrics.RecordInputCheck("error", nil)
+		return nil
+	}
+	if len(missing) == 0 {
+		metrics.RecordInputCheck("ok", nil)
+		return nil
+	}
+	kinds := make([]string, len(missing))
+	for i, m := range missing {
+		kinds[i] = m.Kind
+	}
+	metrics.RecordInputCheck("missing_inputs", kinds)
+	return missing
+}
+
+// dropMempoolInputs returns the missing inputs that were not produced by a
+// transaction in the node mempool.
+func dropMempoolInputs(missing []submit.MissingInput, inMempool mempoolTxLookup) ([]submit.MissingInput, error) {
+	var txHashes []string
+	for _, m := range missing {
+		txHash, _, _ := strings.Cut(m.Input, "#")
+		if !slices.Contains(txHashes, txHash) {
+			txHashes = append(txHashes, txHash)
+		}
+	}
+	present, err := inMempool(txHashes)
+	if err != nil {
+		return nil, fmt.Errorf("failure checking the mempool for the producing transactions: %w", err)
+	}
+	var ret []submit.MissingInput
+	for _, m := range missing {
+		if txHash, _, _ := strings.Cut(m.Input, "#"); !present[txHash] {
+			ret = append(ret, m)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// minimalConwayTxHex is a Conway tx with a single zero-hash input, one output
+// and no witnesses.
+const minimalConwayTxHex = "84a300818258200000000000000000000000000000000000000000000000000000000000000000000181a200581d600000000000000000000000000000000000000000000000000000000001" +
+	"1a3b9aca00021a000186a0a0f5f6"
+
+func TestCheckTxInputs(t *testing.T) {
+	txBytes, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	checkTotal := metrics.TxSubmitInputCheckTotal()
+	missingTotal := metrics.TxSubmitMissingInputsTotal()
+	noUTxOs := func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return map[string]ledger.TransactionOutput{}, nil
+	}
+	emptyMempool := func([]string) (map[string]bool, error) {
+		return map[string]bool{}, nil
+	}
+
+	// Missing spent input
+	beforeMissing := testutil.ToFloat64(checkTotal.WithLabelValues("missing_inputs"))
+	beforeInput := testutil.ToFloat64(missingTotal.WithLabelValues("input"))
+	missing := checkTxInputs(txBytes, noUTxOs, emptyMempool)
+	if len(missing) != 1 || missing[0].Kind != "input" {
+		t.Fatalf("expected one missing input, got %v", missing)
+	}
+	if got := testutil.ToFloat64(checkTotal.WithLabelValues("missing_inputs")) - beforeMissing; got != 1 {
+		t.Errorf("missing_inputs: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(missingTotal.WithLabelValues("input")) - beforeInput; got != 1 {
+		t.Errorf("input: expected 1, got %f", got)
+	}
+
+	// Node errors fail open
+	beforeError := testutil.ToFloat64(checkTotal.WithLabelValues("error"))
+	missing = checkTxInputs(txBytes, func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return nil, errors.New("node unavailable")
+	}, emptyMempool)
+	if missing != nil {
+		t.Errorf("expected no missing inputs on node error, got %v", missing)
+	}
+	missing = checkTxInputs(txBytes, noUTxOs, func([]string) (map[string]bool, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if missing != nil {
+		t.Errorf("expected no missing inputs on mempool error, got %v", missing)
+	}
+	if got := testutil.ToFloat64(checkTotal.WithLabelValues("error")) - beforeError; got != 2 {
+		t.Errorf("error: expected 2, got %f", got)
+	}
+
+	// Outputs of transactions still in the mempool can be chained on
+	beforeOK := testutil.ToFloat64(checkTotal.WithLabelValues("ok"))
+	missing = checkTxInputs(txBytes, noUTxOs, func(txHashes []string) (map[string]bool, error) {
+		ret := make(map[string]bool)
+		for _, txHash := range txHashes {
+			ret[txHash] = txHash == strings.Repeat("00", 32)
+		}
+		return ret, nil
+	})
+	if missing != nil {
+		t.Errorf("expected inputs of mempool transactions to be present, got %v", missing)
+	}
+	if got := testutil.ToFloat64(checkTotal.WithLabelValues("ok")) - beforeOK; got != 1 {
+		t.Errorf("ok: expected 1, got %f", got)
+	}
+}
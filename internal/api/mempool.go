@@ -0,0 +1,418 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// maxPendingTxs bounds the number of accepted transactions remembered by
+// pendingTxs. The node mempool only holds a few blocks worth of transactions,
+// so this is far above what is needed in practice.
+const maxPendingTxs = 4096
+
+// pendingTxTracker remembers transactions accepted through this API until the
+// mempool collector sees that they have left the node mempool.
+type pendingTxTracker struct {
+	mu  sync.Mutex
+	txs map[string]time.Time
+}
+
+var pendingTxs = newPendingTxTracker()
+
+func newPendingTxTracker() *pendingTxTracker {
+	return &pendingTxTracker{txs: make(map[string]time.Time)}
+}
+
+// add records an accepted transaction hash, evicting the oldest entry when
+// the tracker is full.
+func (p *pendingTxTracker) add(txHash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.txs[txHash]; !ok && len(p.txs) >= maxPendingTxs {
+		var oldestHash string
+		var oldest time.Time
+		for h, t := range p.txs {
+			if oldestHash == "" || t.Before(oldest) {
+				oldestHash, oldest = h, t
+			}
+		}
+		delete(p.txs, oldestHash)
+	}
+	p.txs[txHash] = time.Now()
+}
+
+func (p *pendingTxTracker) has(txHash string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.txs[txHash]
+	return ok
+}
+
+func (p *pendingTxTracker) hashes() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := make([]string, 0, len(p.txs))
+	for h := range p.txs {
+		ret = append(ret, h)
+	}
+	return ret
+}
+
+func (p *pendingTxTracker) remove(txHash string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.txs, txHash)
+}
+
+// mempoolState holds the latest node mempool snapshot taken by the background
+// mempool collector.
+type mempoolState struct {
+	mu        sync.RWMutex
+	sizes     submit.MempoolSizes
+	lastCheck time.Time
+}
+
+var mempoolStatus = &mempoolState{}
+
+func (m *mempoolState) update(sizes submit.MempoolSizes) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sizes = sizes
+	m.lastCheck = time.Now()
+}
+
+// fillRatio returns the share of the mempool capacity in use as of the last
+// snapshot. It reports false when there is no snapshot newer than maxAge, so
+// callers fail open when the collector can't reach the node.
+func (m *mempoolState) fillRatio(maxAge time.Duration) (float64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.lastCheck.IsZero() || time.Since(m.lastCheck) > maxAge {
+		return 0, false
+	}
+	if m.sizes.CapacityBytes == 0 {
+		return 0, false
+	}
+	return float64(m.sizes.SizeBytes) / float64(m.sizes.CapacityBytes), true
+}
+
+// admitTx reports whether a new submission should be let through given the
+// configured admission threshold. It always admits when the threshold is 0 or
+// the mempool collector is disabled.
+func (m *mempoolState) admitTx(cfg *config.Config) (bool, float64) {
+	if cfg.Mempool.AdmissionThreshold <= 0 || cfg.Mempool.MetricsInterval == 0 {
+		return true, 0
+	}
+	// Allow for one missed collection plus the time a collection may take.
+	interval := time.Duration(cfg.Mempool.MetricsInterval) * time.Second // #nosec G115
+	timeout := time.Duration(cfg.Node.Timeout) * time.Second             // #nosec G115
+	ratio, ok := m.fillRatio(2*interval + timeout)
+	if !ok {
+		return true, 0
+	}
+	return ratio < cfg.Mempool.AdmissionThreshold, ratio
+}
+
+// startMempoolCollector runs a background goroutine that periodically acquires
+// a node mempool snapshot, caches it in state and exports its sizes, along with
+// the share of it taken up by transactions accepted through this API. It is
+// disabled when cfg.Mempool.MetricsInterval is 0.
+func startMempoolCollector(ctx context.Context, cfg *config.Config, state *mempoolState, pending *pendingTxTracker) {
+	if cfg.Mempool.MetricsInterval == 0 {
+		return
+	}
+	logger := logging.GetLogger()
+	collect := func() {
+		txHashes := pending.hashes()
+		txHashBytes := make([][]byte, 0, len(txHashes))
+		for _, txHash := range txHashes {
+			b, err := hex.DecodeString(txHash)
+			if err != nil {
+				pending.remove(txHash)
+				continue
+			}
+			txHashBytes = append(txHashBytes, b)
+		}
+		sizes, present, err := submit.GetMempoolStatus(nodeQueryConfig(cfg), txHashBytes)
+		if err != nil {
+			logger.Warn("failed to collect mempool metrics", "err", err)
+			return
+		}
+		state.update(*sizes)
+		ownPending := 0
+		for i, ok := range present {
+			if ok {
+				ownPending++
+			} else {
+				pending.remove(hex.EncodeToString(txHashBytes[i]))
+			}
+		}
+		metrics.RecordMempool(sizes.CapacityBytes, sizes.SizeBytes, sizes.TxCount, ownPending)
+	}
+
+	go func() {
+		collect()
+		ticker := time.NewTicker(time.Duration(cfg.Mempool.MetricsInterval) * time.Second) // #nosec G115
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				collect()
+			}
+		}
+	}()
+}
+
+// queryLimiter bounds the number of in-flight node queries for a group of
+// endpoints, so a burst of API requests can't overload the node.
+type queryLimiter struct {
+	slots chan struct{}
+}
+
+func newQueryLimiter(size uint) *queryLimiter {
+	if size == 0 {
+		size = 1
+	}
+	return &queryLimiter{slots: make(chan struct{}, size)}
+}
+
+// tryAcquire reserves a query slot without blocking. Callers must call release
+// when it returns true.
+func (l *queryLimiter) tryAcquire() bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *queryLimiter) release() {
+	<-l.slots
+}
+
+// writeQueryLimitReached responds to a request that was refused by a
+// queryLimiter.
+func writeQueryLimitReached(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "1")
+	writeJSON(w, http.StatusTooManyRequests, "too many concurrent node queries, retry later")
+}
+
+// parsePagination reads the offset and limit query parameters. A missing limit
+// falls back to defaultLimit and any limit above maxLimit is clamped.
+func parsePagination(r *http.Request, defaultLimit, maxLimit uint) (int, int, error) {
+	offset, limit := 0, int(defaultLimit) // #nosec G115
+	if v := r.URL.Query().Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, errors.New("invalid offset: must be a non-negative integer")
+		}
+		offset = n
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, 0, errors.New("invalid limit: must be a positive integer")
+		}
+		limit = n
+	}
+	if maxLimit > 0 && limit > int(maxLimit) { // #nosec G115
+		limit = int(maxLimit) // #nosec G115
+	}
+	return offset, limit, nil
+}
+
+// nodeQueryConfig builds the submit.Config used for node queries from the
+// application config.
+func nodeQueryConfig(cfg *config.Config) *submit.Config {
+	return &submit.Config{
+		Network:      cfg.Node.Network,
+		NetworkMagic: cfg.Node.NetworkMagic,
+		NodeAddress:  cfg.Node.Address,
+		NodePort:     cfg.Node.Port,
+		SocketPath:   cfg.Node.SocketPath,
+		Timeout:      cfg.Node.Timeout,
+		Clock:        genesisClock,
+	}
+}
+
+type mempoolTxResponse struct {
+	Hash string         `json:"hash"`
+	Size int            `json:"size"`
+	Info *submit.TxInfo `json:"info,omitempty"`
+}
+
+type mempoolTxsResponse struct {
+	Offset  int                 `json:"offset"`
+	Limit   int                 `json:"limit"`
+	HasMore bool                `json:"hasMore"`
+	Txs     []mempoolTxResponse `json:"txs"`
+}
+
+// handleMempool godoc
+//
+//	@Summary		Mempool
+//	@Description	Return the capacity, size and transaction count of the node mempool.
+//	@Description	The slot the snapshot was acquired at is not included, as the LocalTxMonitor client does not expose it.
+//	@Produce		json
+//	@Success		200	{object}	submit.MempoolSizes	"Ok"
+//	@Failure		429	{object}	string				"Too Many Requests"
+//	@Failure		500	{object}	string				"Server Error"
+//	@Router			/api/mempool [get]
+func handleMempool(w http.ResponseWriter, _ *http.Request, ql *queryLimiter) {
+	if !ql.tryAcquire() {
+		writeQueryLimitReached(w)
+		return
+	}
+	defer ql.release()
+
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	sizes, err := submit.GetMempoolSizes(nodeQueryConfig(cfg))
+	if err != nil {
+		logger.Error("failure getting mempool sizes", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	writeJSON(w, http.StatusOK, sizes)
+}
+
+// handleMempoolTxs godoc
+//
+//	@Summary		Mempool Txs
+//	@Description	List the hashes of transactions in the node mempool.
+//	@Description	Each request reads a new mempool snapshot, so pages can skip or repeat transactions when the mempool changes between requests.
+//	@Produce		json
+//	@Param			offset	query		int					false	"Number of transactions to skip"
+//	@Param			limit	query		int					false	"Maximum number of transactions to return"
+//	@Param			info	query		bool				false	"Include a decoded content summary per transaction"
+//	@Success		200		{object}	mempoolTxsResponse	"Ok"
+//	@Failure		400		{object}	string				"Bad Request"
+//	@Failure		429		{object}	string				"Too Many Requests"
+//	@Failure		500		{object}	string				"Server Error"
+//	@Router			/api/mempool/txs [get]
+func handleMempoolTxs(w http.ResponseWriter, r *http.Request, ql *queryLimiter) {
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	offset, limit, err := parsePagination(r, cfg.Mempool.DefaultPageSize, cfg.Mempool.MaxPageSize)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	withInfo := false
+	if v := r.URL.Query().Get("info"); v != "" {
+		withInfo, err = strconv.ParseBool(v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, "invalid info: must be a boolean")
+			return
+		}
+	}
+
+	if !ql.tryAcquire() {
+		writeQueryLimitReached(w)
+		return
+	}
+	defer ql.release()
+
+	txs, hasMore, err := submit.ListMempoolTxs(nodeQueryConfig(cfg), offset, limit)
+	if err != nil {
+		logger.Error("failure listing mempool transactions", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+
+	resp := mempoolTxsResponse{
+		Offset:  offset,
+		Limit:   limit,
+		HasMore: hasMore,
+		Txs:     make([]mempoolTxResponse, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		if tx.Hash == "" {
+			logger.Warn("failed to decode mempool transaction", "size", len(tx.Cbor))
+		}
+		item := mempoolTxResponse{
+			Hash: tx.Hash,
+			Size: len(tx.Cbor),
+		}
+		if withInfo {
+			item.Info, err = submit.ParseTxInfo(tx.Cbor)
+			if err != nil {
+				logger.Warn("failed to parse mempool tx content signals", "err", err, "txHash", tx.Hash)
+			}
+		}
+		resp.Txs = append(resp.Txs, item)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleMempoolTx godoc
+//
+//	@Summary		Mempool Tx
+//	@Description	Return the raw CBOR of a transaction in the node mempool.
+//	@Produce		application/cbor
+//	@Param			tx_hash	path		string	true	"Transaction Hash"
+//	@Success		200		{object}	string	"Ok"
+//	@Failure		400		{object}	string	"Bad Request"
+//	@Failure		404		{object}	string	"Not Found"
+//	@Failure		429		{object}	string	"Too Many Requests"
+//	@Failure		500		{object}	string	"Server Error"
+//	@Router			/api/mempool/txs/{tx_hash} [get]
+func handleMempoolTx(w http.ResponseWriter, r *http.Request, ql *queryLimiter) {
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	txHashBytes, err := hex.DecodeString(r.PathValue("tx_hash"))
+	if err != nil || len(txHashBytes) != 32 {
+		writeJSON(w, http.StatusBadRequest, "invalid transaction hash: must be 32 hex-encoded bytes")
+		return
+	}
+
+	if !ql.tryAcquire() {
+		writeQueryLimitReached(w)
+		return
+	}
+	defer ql.release()
+
+	txBytes, err := submit.GetMempoolTx(nodeQueryConfig(cfg), txHashBytes)
+	if err != nil {
+		logger.Error("failure getting mempool transaction", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	if txBytes == nil {
+		writeJSON(w, http.StatusNotFound, "transaction not found in mempool")
+		return
+	}
+	w.Header().Set("Content-Type", "application/cbor")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(txBytes)
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+func TestParsePagination(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		query      string
+		wantOffset int
+		wantLimit  int
+		wantErr    bool
+	}{
+		{name: "defaults", query: "", wantOffset: 0, wantLimit: 100},
+		{name: "explicit values", query: "offset=20&limit=10", wantOffset: 20, wantLimit: 10},
+		{name: "limit clamped to max", query: "limit=10000", wantOffset: 0, wantLimit: 500},
+		{name: "negative offset", query: "offset=-1", wantErr: true},
+		{name: "zero limit", query: "limit=0", wantErr: true},
+		{name: "non-numeric limit", query: "limit=abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/api/mempool/txs?"+tt.query, nil)
+			offset, limit, err := parsePagination(req, 100, 500)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if offset != tt.wantOffset || limit != tt.wantLimit {
+				t.Errorf("want offset=%d limit=%d, got offset=%d limit=%d",
+					tt.wantOffset, tt.wantLimit, offset, limit)
+			}
+		})
+	}
+}
+
+func TestQueryLimiter(t *testing.T) {
+	t.Parallel()
+	ql := newQueryLimiter(1)
+	if !ql.tryAcquire() {
+		t.Fatal("expected first acquire to succeed")
+	}
+	if ql.tryAcquire() {
+		t.Fatal("expected second acquire to fail while slot is held")
+	}
+	ql.release()
+	if !ql.tryAcquire() {
+		t.Fatal("expected acquire to succeed after release")
+	}
+}
+
+func TestMempoolHandlers_BadRequest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "tx hash not hex", path: "/api/mempool/txs/not-hex"},
+		{name: "tx hash wrong length", path: "/api/mempool/txs/abc123"},
+		{name: "invalid limit", path: "/api/mempool/txs?limit=-5"},
+		{name: "invalid info flag", path: "/api/mempool/txs?info=maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newTestMux(&nodeHealthState{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestMempoolHandlers_QueryLimitReached(t *testing.T) {
+	t.Parallel()
+	ql := newQueryLimiter(1)
+	if !ql.tryAcquire() {
+		t.Fatal("expected acquire to succeed")
+	}
+	defer ql.release()
+
+	rec := httptest.NewRecorder()
+	handleMempool(rec, httptest.NewRequest(http.MethodGet, "/api/mempool", nil), ql)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if v := rec.Header().Get("Retry-After"); v == "" {
+		t.Error("expected Retry-After header to be set")
+	}
+}
+
+func TestMempool_NoNode(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	newTestMux(&nodeHealthState{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/mempool", nil))
+
+	// No node available → 500
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "failure communicating with node") {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+}
+
+func TestPendingTxTracker(t *testing.T) {
+	t.Parallel()
+	p := newPendingTxTracker()
+	p.add("aa")
+	p.add("bb")
+	p.add("aa")
+	if got := len(p.hashes()); got != 2 {
+		t.Fatalf("expected 2 tracked hashes, got %d", got)
+	}
+	p.remove("aa")
+	hashes := p.hashes()
+	if len(hashes) != 1 || hashes[0] != "bb" {
+		t.Errorf("expected only bb to remain, got %v", hashes)
+	}
+}
+
+func TestPendingTxTracker_EvictsOldest(t *testing.T) {
+	t.Parallel()
+	p := newPendingTxTracker()
+	for i := range maxPendingTxs {
+		p.add(fmt.Sprintf("%064x", i))
+	}
+	// Make the first entry unambiguously the oldest.
+	p.txs[fmt.Sprintf("%064x", 0)] = time.Time{}
+	p.add("new")
+	if got := len(p.hashes()); got != maxPendingTxs {
+		t.Fatalf("expected %d tracked hashes, got %d", maxPendingTxs, got)
+	}
+	if _, ok := p.txs[fmt.Sprintf("%064x", 0)]; ok {
+		t.Error("expected oldest hash to be evicted")
+	}
+	if _, ok := p.txs["new"]; !ok {
+		t.Error("expected new hash to be tracked")
+	}
+}
+
+func TestStartMempoolCollector_Disabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Mempool.MetricsInterval = 0
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("startMempoolCollector panicked: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startMempoolCollector(ctx, cfg, &mempoolState{}, newPendingTxTracker())
+}
+
+func TestMempoolState_AdmitTx(t *testing.T) {
+	t.Parallel()
+	newCfg := func(threshold float64) *config.Config {
+		cfg := &config.Config{}
+		cfg.Node.Timeout = 1
+		cfg.Mempool.MetricsInterval = 30
+		cfg.Mempool.AdmissionThreshold = threshold
+		return cfg
+	}
+	tests := []struct {
+		name      string
+		cfg       *config.Config
+		sizes     *submit.MempoolSizes
+		lastCheck time.Time
+		wantAdmit bool
+	}{
+		{
+			name:      "threshold disabled",
+			cfg:       newCfg(0),
+			sizes:     &submit.MempoolSizes{CapacityBytes: 100, SizeBytes: 100},
+			lastCheck: time.Now(),
+			wantAdmit: true,
+		},
+		{
+			name:      "no snapshot yet",
+			cfg:       newCfg(0.9),
+			wantAdmit: true,
+		},
+		{
+			name:      "below threshold",
+			cfg:       newCfg(0.9),
+			sizes:     &submit.MempoolSizes{CapacityBytes: 100, SizeBytes: 50},
+			lastCheck: time.Now(),
+			wantAdmit: true,
+		},
+		{
+			name:      "at threshold",
+			cfg:       newCfg(0.9),
+			sizes:     &submit.MempoolSizes{CapacityBytes: 100, SizeBytes: 90},
+			lastCheck: time.Now(),
+			wantAdmit: false,
+		},
+		{
+			name:      "stale snapshot fails open",
+			cfg:       newCfg(0.9),
+			sizes:     &submit.MempoolSizes{CapacityBytes: 100, SizeBytes: 100},
+			lastCheck: time.Now().Add(-time.Hour),
+			wantAdmit: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := &mempoolState{lastCheck: tt.lastCheck}
+			if tt.sizes != nil {
+				m.sizes = *tt.sizes
+			}
+			if admit, _ := m.admitTx(tt.cfg); admit != tt.wantAdmit {
+				t.Errorf("want admit=%v, got %v", tt.wantAdmit, admit)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// nodeNetworkId returns the address network ID of the configured network.
+func nodeNetworkId(cfg *config.Config) uint {
+	if cfg.Node.NetworkMagic == 0 {
+		if network, ok := ouroboros.NetworkByName(cfg.Node.Network); ok {
+			return uint(network.Id)
+		}
+	}
+	return submit.NetworkIdForMagic(cfg.Node.NetworkMagic)
+}
+
+// checkTxNetwork returns an error message naming the addresses of a
+// transaction that are for a different network than the node, or an empty
+// string if there are none. Transactions that can't be decoded are left to
+// the node to reject.
+func checkTxNetwork(cfg *config.Config, txRawBytes []byte) string {
+	networkId := nodeNetworkId(cfg)
+	mismatches, err := submit.FindNetworkMismatches(txRawBytes, networkId)
+	if err != nil || len(mismatches) == 0 {
+		return ""
+	}
+	details := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		details[i] = m.String()
+	}
+	network := cfg.Node.Network
+	if network == "" {
+		network = fmt.Sprintf("network magic %d", cfg.Node.NetworkMagic)
+	}
+	return fmt.Sprintf(
+		"transaction is for a different network than this node (%s, network ID %d): %s",
+		network,
+		networkId,
+		strings.Join(details, "; "),
+	)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+)
+
+func TestCheckTxNetwork(t *testing.T) {
+	t.Parallel()
+	txBytes, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	// minimalConwayTxHex pays to a testnet address
+	mainnet := &config.Config{Node: config.NodeConfig{Network: "mainnet"}}
+	msg := checkTxNetwork(mainnet, txBytes)
+	if !strings.Contains(msg, minimalConwayTxAddress(t)) {
+		t.Errorf("expected message to name the output address, got %q", msg)
+	}
+	preview := &config.Config{Node: config.NodeConfig{Network: "preview", NetworkMagic: 2}}
+	if msg := checkTxNetwork(preview, txBytes); msg != "" {
+		t.Errorf("expected no mismatch on preview, got %q", msg)
+	}
+	if msg := checkTxNetwork(mainnet, []byte("not-valid-cbor")); msg != "" {
+		t.Errorf("expected undecodable tx to be left to the node, got %q", msg)
+	}
+}
+
+func TestSubmitTx_WrongNetwork(t *testing.T) {
+	t.Parallel()
+	txBytes, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/submit/tx", bytes.NewReader(txBytes))
+	req.Header.Set("Content-Type", "application/cbor")
+	newTestMux(&nodeHealthState{}).ServeHTTP(rec, req)
+
+	// The default config is mainnet, so the testnet output is refused before
+	// the node is contacted
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), minimalConwayTxAddress(t)) {
+		t.Errorf("expected response to name the output address, got %s", rec.Body.String())
+	}
+}
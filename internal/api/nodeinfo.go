@@ -0,0 +1,178 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// nodeInfoCache holds the latest node status queried over LocalStateQuery. It
+// is kept fresh by startNodeInfoRefresher and falls back to querying the node
+// inline when the cached status is older than ttl.
+type nodeInfoCache struct {
+	mu        sync.RWMutex
+	status    *submit.NodeStatus
+	updated   time.Time
+	refreshMu sync.Mutex
+	ttl       time.Duration
+	fetch     func() (*submit.NodeStatus, error)
+}
+
+var (
+	nodeInfo     *nodeInfoCache
+	nodeInfoOnce sync.Once
+	// genesisClock is built from the configured Shelley genesis file, if any,
+	// and replaces the node era history for slot to time conversion.
+	genesisClock *submit.SlotClock
+)
+
+func newNodeInfoCache(ttl time.Duration, fetch func() (*submit.NodeStatus, error)) *nodeInfoCache {
+	return &nodeInfoCache{ttl: ttl, fetch: fetch}
+}
+
+// cached returns the cached status and when it was fetched, if it is no older
+// than the cache TTL.
+func (c *nodeInfoCache) cached() (*submit.NodeStatus, time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.status == nil || time.Since(c.updated) > c.ttl {
+		return nil, time.Time{}, false
+	}
+	return c.status, c.updated, true
+}
+
+// get returns the cached status, querying the node first if it is stale.
+func (c *nodeInfoCache) get() (*submit.NodeStatus, time.Time, error) {
+	if status, updated, ok := c.cached(); ok {
+		return status, updated, nil
+	}
+	// Only one caller queries the node at a time, the rest pick up its result.
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if status, updated, ok := c.cached(); ok {
+		return status, updated, nil
+	}
+	return c.refreshLocked()
+}
+
+func (c *nodeInfoCache) refresh() (*submit.NodeStatus, time.Time, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refreshLocked()
+}
+
+func (c *nodeInfoCache) refreshLocked() (*submit.NodeStatus, time.Time, error) {
+	status, err := c.fetch()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now := time.Now()
+	c.mu.Lock()
+	c.status = status
+	c.updated = now
+	c.mu.Unlock()
+	metrics.RecordNodeEra(status.Era.Name)
+	return status, now, nil
+}
+
+// startNodeInfoRefresher runs a background goroutine that refreshes cache at
+// half its TTL, so requests are normally served from the cache. It does
+// nothing when caching is disabled.
+func startNodeInfoRefresher(ctx context.Context, cache *nodeInfoCache) {
+	if cache.ttl <= 0 {
+		return
+	}
+	logger := logging.GetLogger()
+	interval := max(cache.ttl/2, time.Second)
+	go func() {
+		for {
+			if _, _, err := cache.refresh(); err != nil {
+				logger.Warn("failed to refresh node info", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
+
+// getNodeInfoCache returns the process-wide node info cache, creating it from
+// cfg on first use.
+func getNodeInfoCache(cfg *config.Config) *nodeInfoCache {
+	nodeInfoOnce.Do(func() {
+		ttl := time.Duration(cfg.Node.InfoCacheTTL) * time.Second // #nosec G115
+		nodeInfo = newNodeInfoCache(ttl, func() (*submit.NodeStatus, error) {
+			return submit.QueryNodeStatus(nodeQueryConfig(cfg))
+		})
+	})
+	return nodeInfo
+}
+
+type nodeInfoResponse struct {
+	ProtocolVersion uint16          `json:"protocolVersion"`
+	NetworkMagic    uint32          `json:"networkMagic"`
+	Network         string          `json:"network,omitempty"`
+	Era             string          `json:"era"`
+	EraId           uint8           `json:"eraId"`
+	Epoch           uint64          `json:"epoch"`
+	Tip             submit.ChainTip `json:"tip"`
+	SystemStart     time.Time       `json:"systemStart"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+// handleNodeInfo godoc
+//
+//	@Summary		Node Info
+//	@Description	Return the negotiated NtC protocol version, network, current era, epoch, chain tip and system start of the node.
+//	@Description	Values are cached for a short time and refreshed in the background.
+//	@Produce		json
+//	@Success		200	{object}	nodeInfoResponse	"Ok"
+//	@Failure		500	{object}	string				"Server Error"
+//	@Router			/api/node/info [get]
+func handleNodeInfo(w http.ResponseWriter, _ *http.Request, cache *nodeInfoCache) {
+	logger := logging.GetLogger()
+
+	status, updated, err := cache.get()
+	if err != nil {
+		logger.Error("failure getting node info", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	resp := nodeInfoResponse{
+		ProtocolVersion: status.ProtocolVersion,
+		NetworkMagic:    status.NetworkMagic,
+		Era:             status.Era.Name,
+		EraId:           status.Era.Id,
+		Epoch:           status.Epoch,
+		Tip:             status.Tip,
+		SystemStart:     status.Clock.SystemStart,
+		UpdatedAt:       updated.UTC(),
+	}
+	if network, ok := ouroboros.NetworkByNetworkMagic(status.NetworkMagic); ok {
+		resp.Network = network.Name
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
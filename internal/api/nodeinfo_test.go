@@ -0,0 +1,141 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+func testNodeStatus(t *testing.T) *submit.NodeStatus {
+	t.Helper()
+	clock, err := submit.NewSlotClock(
+		time.Date(2022, time.October, 25, 0, 0, 0, 0, time.UTC),
+		[]submit.EraSummary{{SlotLength: time.Second, EpochLength: 86400}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return &submit.NodeStatus{
+		ProtocolVersion: 16,
+		NetworkMagic:    2,
+		Era:             ledger.GetEraById(ledger.EraIdConway),
+		Epoch:           900,
+		Tip:             submit.ChainTip{Slot: 77760000, Hash: "abcd", BlockNo: 3000000},
+		Clock:           clock,
+	}
+}
+
+func TestNodeInfoCache_Get(t *testing.T) {
+	t.Parallel()
+	status := testNodeStatus(t)
+	var calls atomic.Int32
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		calls.Add(1)
+		return status, nil
+	})
+	for range 3 {
+		got, _, err := cache.get()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != status {
+			t.Fatal("expected cached status to be returned")
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 node query, got %d", n)
+	}
+}
+
+func TestNodeInfoCache_Stale(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		calls.Add(1)
+		return &submit.NodeStatus{}, nil
+	})
+	if _, _, err := cache.get(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cache.updated = time.Now().Add(-2 * time.Minute)
+	if _, _, err := cache.get(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected stale entry to be refreshed, got %d node queries", n)
+	}
+}
+
+func TestNodeInfoCache_Error(t *testing.T) {
+	t.Parallel()
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if _, _, err := cache.get(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, _, ok := cache.cached(); ok {
+		t.Error("expected failed query not to be cached")
+	}
+}
+
+func TestHandleNodeInfo(t *testing.T) {
+	t.Parallel()
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return testNodeStatus(t), nil
+	})
+	rec := httptest.NewRecorder()
+	handleNodeInfo(rec, httptest.NewRequest(http.MethodGet, "/api/node/info", nil), cache)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp nodeInfoResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if resp.Network != "preview" {
+		t.Errorf("expected network preview, got %q", resp.Network)
+	}
+	if resp.Era != "Conway" || resp.EraId != ledger.EraIdConway {
+		t.Errorf("unexpected era %q (%d)", resp.Era, resp.EraId)
+	}
+	if resp.Epoch != 900 || resp.Tip.Slot != 77760000 || resp.Tip.BlockNo != 3000000 {
+		t.Errorf("unexpected epoch or tip: %+v", resp)
+	}
+	if !resp.SystemStart.Equal(time.Date(2022, time.October, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected system start %s", resp.SystemStart)
+	}
+}
+
+func TestHandleNodeInfo_NoNode(t *testing.T) {
+	t.Parallel()
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return nil, errors.New("dial failed")
+	})
+	rec := httptest.NewRecorder()
+	handleNodeInfo(rec, httptest.NewRequest(http.MethodGet, "/api/node/info", nil), cache)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
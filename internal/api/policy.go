@@ -0,0 +1,168 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type policyDeniedResponse struct {
+	Error string `json:"error"`
+	Rule  string `json:"rule"`
+	Value string `json:"value"`
+}
+
+// admissionPolicyState holds the current admission policy, compiled from the
+// rules in the config and the rules file, and the rules file version it was
+// loaded from.
+type admissionPolicyState struct {
+	mu      sync.RWMutex
+	policy  *submit.Policy
+	modTime time.Time
+	size    int64
+}
+
+var admissionPolicy = &admissionPolicyState{}
+
+func (s *admissionPolicyState) get() *submit.Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+// load compiles the admission policy. The current policy is kept if the rules
+// file can't be read or contains invalid rules.
+func (s *admissionPolicyState) load(cfg *config.Config) error {
+	rules := []submit.PolicyRules{cfg.Submit.Policy}
+	var modTime time.Time
+	var size int64
+	if cfg.Submit.PolicyFile != "" {
+		info, err := os.Stat(cfg.Submit.PolicyFile)
+		if err != nil {
+			return err
+		}
+		modTime, size = info.ModTime(), info.Size()
+		fileRules, err := submit.LoadPolicyRules(cfg.Submit.PolicyFile)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, *fileRules)
+	}
+	policy, err := submit.NewPolicy(rules...)
+	if err != nil {
+		return err
+	}
+	policy.ObserveExpressions(metrics.RecordAdmissionExpression)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = policy
+	s.modTime = modTime
+	s.size = size
+	return nil
+}
+
+// changed reports whether the rules file differs from the one the current
+// policy was loaded from.
+func (s *admissionPolicyState) changed(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		// Let load report the error
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+}
+
+// startPolicyReloader reloads the admission policy in the background whenever
+// the rules file changes, so rules can be updated without a restart.
+func startPolicyReloader(ctx context.Context, cfg *config.Config, state *admissionPolicyState) {
+	if cfg.Submit.PolicyFile == "" || cfg.Submit.PolicyReloadInterval == 0 {
+		return
+	}
+	logger := logging.GetLogger()
+	go func() {
+		ticker := time.NewTicker(time.Duration(cfg.Submit.PolicyReloadInterval) * time.Second) // #nosec G115
+		defer ticker.Stop()
+		var lastErr string
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !state.changed(cfg.Submit.PolicyFile) {
+					continue
+				}
+				if err := state.load(cfg); err != nil {
+					// Only log each distinct failure once, as the file is
+					// checked again on every tick until it is fixed
+					if err.Error() != lastErr {
+						logger.Error("failed to reload admission policy, keeping current rules", "err", err)
+						metrics.RecordPolicyReload("error")
+						lastErr = err.Error()
+					}
+					continue
+				}
+				lastErr = ""
+				logger.Info("reloaded admission policy", "file", cfg.Submit.PolicyFile)
+				metrics.RecordPolicyReload("ok")
+			}
+		}
+	}()
+}
+
+// checkAdmissionPolicy evaluates the admission policy against a transaction.
+// It returns the status and body to refuse the transaction with, or a status
+// of 0 if the transaction is admitted. Transactions that can't be evaluated
+// are refused, as the policy can't vouch for them.
+func checkAdmissionPolicy(
+	policy *submit.Policy,
+	txRawBytes []byte,
+	resolve submit.UTxOResolver,
+) (int, any) {
+	logger := logging.GetLogger()
+	var nodeErr error
+	violation, err := policy.Evaluate(txRawBytes, func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		utxos, err := resolve(inputs)
+		nodeErr = err
+		return utxos, err
+	})
+	if err != nil {
+		if nodeErr != nil {
+			logger.Error("failure resolving transaction inputs for admission policy", "err", err)
+			return http.StatusServiceUnavailable, "failure communicating with node"
+		}
+		return http.StatusBadRequest, "unable to decode transaction: " + err.Error()
+	}
+	if violation == nil {
+		return 0, nil
+	}
+	metrics.RecordPolicyDenied(violation.Rule)
+	return http.StatusForbidden, policyDeniedResponse{
+		Error: violation.Error(),
+		Rule:  violation.Rule,
+		Value: violation.Value,
+	}
+}
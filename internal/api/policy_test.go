@@ -0,0 +1,146 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// minimalConwayTxAddress returns the output address of minimalConwayTxHex.
+func minimalConwayTxAddress(t *testing.T) string {
+	t.Helper()
+	addr, err := lcommon.NewAddressFromBytes(append([]byte{0x60}, make([]byte, 28)...))
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	return addr.String()
+}
+
+func TestCheckAdmissionPolicy(t *testing.T) {
+	txBytes, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	policy, err := submit.NewPolicy(submit.PolicyRules{
+		Addresses: submit.PolicyList{Deny: []string{minimalConwayTxAddress(t)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	noUTxOs := func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return map[string]ledger.TransactionOutput{}, nil
+	}
+
+	status, body := checkAdmissionPolicy(policy, txBytes, noUTxOs)
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", status)
+	}
+	resp, ok := body.(policyDeniedResponse)
+	if !ok || resp.Rule != "addresses.deny" || resp.Value != minimalConwayTxAddress(t) {
+		t.Errorf("unexpected response body: %+v", body)
+	}
+
+	status, _ = checkAdmissionPolicy(policy, txBytes, func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 when node can't be queried, got %d", status)
+	}
+
+	status, _ = checkAdmissionPolicy(policy, []byte("not-valid-cbor"), noUTxOs)
+	if status != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid CBOR, got %d", status)
+	}
+
+	admitAll, err := submit.NewPolicy(submit.PolicyRules{
+		MetadataLabels: submit.PolicyList{Deny: []string{"674"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if status, _ := checkAdmissionPolicy(admitAll, txBytes, noUTxOs); status != 0 {
+		t.Errorf("expected transaction to be admitted, got %d", status)
+	}
+}
+
+func TestAdmissionPolicyState_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte("metadataLabels:\n  deny: [674]\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %s", err)
+	}
+	cfg := &config.Config{Submit: config.SubmitConfig{PolicyFile: path}}
+	state := &admissionPolicyState{}
+	if err := state.load(cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	first := state.get()
+	if first == nil || first.Empty() {
+		t.Fatal("expected rules to be loaded from file")
+	}
+	if state.changed(path) {
+		t.Error("expected rules file to be unchanged after load")
+	}
+
+	// Invalid rules keep the current policy
+	if err := os.WriteFile(path, []byte("metadataLabels:\n  deny: [not-a-label]\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %s", err)
+	}
+	bumpModTime(t, path)
+	if !state.changed(path) {
+		t.Fatal("expected rules file change to be detected")
+	}
+	if err := state.load(cfg); err == nil {
+		t.Error("expected error for invalid rules")
+	}
+	if state.get() != first {
+		t.Error("expected current policy to be kept after a failed reload")
+	}
+
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %s", err)
+	}
+	bumpModTime(t, path)
+	if err := state.load(cfg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !state.get().Empty() {
+		t.Error("expected reloaded policy to be empty")
+	}
+}
+
+// bumpModTime moves the modification time of a file forward, so a rewrite is
+// detected on filesystems with coarse timestamps.
+func bumpModTime(t *testing.T, path string) {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat file: %s", err)
+	}
+	modTime := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("failed to set modification time: %s", err)
+	}
+}
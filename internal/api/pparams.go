@@ -0,0 +1,300 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// protocolParamsMinRefresh is the shortest time protocol parameters are
+// cached for. It covers the window after an epoch boundary where the node
+// tip has not reached the new epoch yet.
+const protocolParamsMinRefresh = 30 * time.Second
+
+// protocolParamsCache holds the protocol parameters of the current epoch.
+// Parameter updates only take effect at epoch boundaries, so the cached
+// parameters are kept until the next epoch starts.
+type protocolParamsCache struct {
+	mu        sync.RWMutex
+	params    *submit.ProtocolParams
+	expires   time.Time
+	refreshMu sync.Mutex
+	fetch     func() (*submit.ProtocolParams, error)
+	// epochEnd returns the time at which epoch ends.
+	epochEnd func(epoch uint64) (time.Time, error)
+}
+
+var (
+	protocolParams     *protocolParamsCache
+	protocolParamsOnce sync.Once
+)
+
+func (c *protocolParamsCache) cached() (*submit.ProtocolParams, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.params == nil || !time.Now().Before(c.expires) {
+		return nil, false
+	}
+	return c.params, true
+}
+
+// get returns the cached parameters, querying the node first if the epoch
+// they were fetched in has ended.
+func (c *protocolParamsCache) get() (*submit.ProtocolParams, error) {
+	if params, ok := c.cached(); ok {
+		return params, nil
+	}
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if params, ok := c.cached(); ok {
+		return params, nil
+	}
+	params, err := c.fetch()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expires := now.Add(protocolParamsMinRefresh)
+	if end, err := c.epochEnd(params.Epoch); err != nil {
+		logging.GetLogger().Warn("failed to determine end of epoch, using minimum protocol parameters cache time",
+			"epoch", params.Epoch, "err", err)
+	} else if end.After(expires) {
+		expires = end
+	}
+	c.mu.Lock()
+	c.params = params
+	c.expires = expires
+	c.mu.Unlock()
+	return params, nil
+}
+
+// getProtocolParamsCache returns the process-wide protocol parameters cache,
+// creating it from cfg on first use. Epoch boundaries are found using the
+// slot clock from the node info cache.
+func getProtocolParamsCache(cfg *config.Config) *protocolParamsCache {
+	protocolParamsOnce.Do(func() {
+		nodeInfo := getNodeInfoCache(cfg)
+		protocolParams = &protocolParamsCache{
+			fetch: func() (*submit.ProtocolParams, error) {
+				return submit.QueryProtocolParams(nodeQueryConfig(cfg))
+			},
+			epochEnd: func(epoch uint64) (time.Time, error) {
+				status, _, err := nodeInfo.get()
+				if err != nil {
+					return time.Time{}, err
+				}
+				slot, err := status.Clock.EpochStartSlot(epoch + 1)
+				if err != nil {
+					return time.Time{}, err
+				}
+				return status.Clock.SlotToTime(slot)
+			},
+		}
+	})
+	return protocolParams
+}
+
+// blockfrostProtocolParams is the protocol parameters layout of the
+// Blockfrost /epochs/latest/parameters endpoint. Lovelace amounts and
+// execution unit limits are strings, as Blockfrost returns them.
+type blockfrostProtocolParams struct {
+	Epoch                      uint64             `json:"epoch"`
+	MinFeeA                    uint64             `json:"min_fee_a"`
+	MinFeeB                    uint64             `json:"min_fee_b"`
+	MaxBlockSize               uint64             `json:"max_block_size"`
+	MaxTxSize                  uint64             `json:"max_tx_size"`
+	MaxBlockHeaderSize         uint64             `json:"max_block_header_size"`
+	KeyDeposit                 string             `json:"key_deposit"`
+	PoolDeposit                string             `json:"pool_deposit"`
+	EMax                       uint64             `json:"e_max"`
+	NOpt                       uint64             `json:"n_opt"`
+	A0                         *submit.Ratio      `json:"a0"`
+	Rho                        *submit.Ratio      `json:"rho"`
+	Tau                        *submit.Ratio      `json:"tau"`
+	DecentralisationParam      *submit.Ratio      `json:"decentralisation_param"`
+	ExtraEntropy               any                `json:"extra_entropy"`
+	ProtocolMajorVer           uint               `json:"protocol_major_ver"`
+	ProtocolMinorVer           uint               `json:"protocol_minor_ver"`
+	MinUtxo                    string             `json:"min_utxo"`
+	MinPoolCost                string             `json:"min_pool_cost"`
+	Nonce                      *string            `json:"nonce"`
+	CostModels                 map[string][]int64 `json:"cost_models"`
+	CostModelsRaw              map[string][]int64 `json:"cost_models_raw"`
+	PriceMem                   *submit.Ratio      `json:"price_mem"`
+	PriceStep                  *submit.Ratio      `json:"price_step"`
+	MaxTxExMem                 *string            `json:"max_tx_ex_mem"`
+	MaxTxExSteps               *string            `json:"max_tx_ex_steps"`
+	MaxBlockExMem              *string            `json:"max_block_ex_mem"`
+	MaxBlockExSteps            *string            `json:"max_block_ex_steps"`
+	MaxValSize                 *string            `json:"max_val_size"`
+	CollateralPercent          *uint64            `json:"collateral_percent"`
+	MaxCollateralInputs        *uint64            `json:"max_collateral_inputs"`
+	CoinsPerUtxoSize           *string            `json:"coins_per_utxo_size"`
+	CoinsPerUtxoWord           *string            `json:"coins_per_utxo_word"`
+	PvtMotionNoConfidence      *submit.Ratio      `json:"pvt_motion_no_confidence"`
+	PvtCommitteeNormal         *submit.Ratio      `json:"pvt_committee_normal"`
+	PvtCommitteeNoConfidence   *submit.Ratio      `json:"pvt_committee_no_confidence"`
+	PvtHardForkInitiation      *submit.Ratio      `json:"pvt_hard_fork_initiation"`
+	PvtPPSecurityGroup         *submit.Ratio      `json:"pvt_p_p_security_group"`
+	DvtMotionNoConfidence      *submit.Ratio      `json:"dvt_motion_no_confidence"`
+	DvtCommitteeNormal         *submit.Ratio      `json:"dvt_committee_normal"`
+	DvtCommitteeNoConfidence   *submit.Ratio      `json:"dvt_committee_no_confidence"`
+	DvtUpdateToConstitution    *submit.Ratio      `json:"dvt_update_to_constitution"`
+	DvtHardForkInitiation      *submit.Ratio      `json:"dvt_hard_fork_initiation"`
+	DvtPPNetworkGroup          *submit.Ratio      `json:"dvt_p_p_network_group"`
+	DvtPPEconomicGroup         *submit.Ratio      `json:"dvt_p_p_economic_group"`
+	DvtPPTechnicalGroup        *submit.Ratio      `json:"dvt_p_p_technical_group"`
+	DvtPPGovGroup              *submit.Ratio      `json:"dvt_p_p_gov_group"`
+	DvtTreasuryWithdrawal      *submit.Ratio      `json:"dvt_treasury_withdrawal"`
+	CommitteeMinSize           *string            `json:"committee_min_size"`
+	CommitteeMaxTermLength     *string            `json:"committee_max_term_length"`
+	GovActionLifetime          *string            `json:"gov_action_lifetime"`
+	GovActionDeposit           *string            `json:"gov_action_deposit"`
+	DrepDeposit                *string            `json:"drep_deposit"`
+	DrepActivity               *string            `json:"drep_activity"`
+	MinFeeRefScriptCostPerByte *submit.Ratio      `json:"min_fee_ref_script_cost_per_byte"`
+}
+
+func newBlockfrostProtocolParams(p *submit.ProtocolParams) *blockfrostProtocolParams {
+	ret := &blockfrostProtocolParams{
+		Epoch:                 p.Epoch,
+		MinFeeA:               p.TxFeePerByte,
+		MinFeeB:               p.TxFeeFixed,
+		MaxBlockSize:          p.MaxBlockBodySize,
+		MaxTxSize:             p.MaxTxSize,
+		MaxBlockHeaderSize:    p.MaxBlockHeaderSize,
+		KeyDeposit:            strconv.FormatUint(p.StakeAddressDeposit, 10),
+		PoolDeposit:           strconv.FormatUint(p.StakePoolDeposit, 10),
+		EMax:                  p.PoolRetireMaxEpoch,
+		NOpt:                  p.StakePoolTargetNum,
+		A0:                    p.PoolPledgeInfluence,
+		Rho:                   p.MonetaryExpansion,
+		Tau:                   p.TreasuryCut,
+		DecentralisationParam: p.Decentralization,
+		ProtocolMajorVer:      p.ProtocolVersion.Major,
+		ProtocolMinorVer:      p.ProtocolVersion.Minor,
+		MinPoolCost:           strconv.FormatUint(p.MinPoolCost, 10),
+		// Blockfrost keys cost_models by parameter name, which the node does
+		// not report, so both fields carry the raw cost model values.
+		CostModels:                 p.CostModels,
+		CostModelsRaw:              p.CostModels,
+		CollateralPercent:          p.CollateralPercentage,
+		MaxCollateralInputs:        p.MaxCollateralInputs,
+		MaxValSize:                 uintString(p.MaxValueSize),
+		CoinsPerUtxoSize:           uintString(p.UtxoCostPerByte),
+		CoinsPerUtxoWord:           uintString(p.UtxoCostPerWord),
+		CommitteeMinSize:           uintString(p.CommitteeMinSize),
+		CommitteeMaxTermLength:     uintString(p.CommitteeMaxTermLength),
+		GovActionLifetime:          uintString(p.GovActionLifetime),
+		GovActionDeposit:           uintString(p.GovActionDeposit),
+		DrepDeposit:                uintString(p.DRepDeposit),
+		DrepActivity:               uintString(p.DRepActivity),
+		MinFeeRefScriptCostPerByte: p.MinFeeRefScriptCostPerByte,
+	}
+	// min_utxo is the per-byte UTxO cost since Babbage
+	switch {
+	case p.MinUTxOValue != nil:
+		ret.MinUtxo = strconv.FormatUint(*p.MinUTxOValue, 10)
+	case p.UtxoCostPerByte != nil:
+		ret.MinUtxo = strconv.FormatUint(*p.UtxoCostPerByte, 10)
+	case p.UtxoCostPerWord != nil:
+		ret.MinUtxo = strconv.FormatUint(*p.UtxoCostPerWord, 10)
+	}
+	if p.ExecutionUnitPrices != nil {
+		ret.PriceMem = p.ExecutionUnitPrices.PriceMemory
+		ret.PriceStep = p.ExecutionUnitPrices.PriceSteps
+	}
+	if p.MaxTxExecutionUnits != nil {
+		ret.MaxTxExMem = intString(p.MaxTxExecutionUnits.Memory)
+		ret.MaxTxExSteps = intString(p.MaxTxExecutionUnits.Steps)
+	}
+	if p.MaxBlockExecutionUnits != nil {
+		ret.MaxBlockExMem = intString(p.MaxBlockExecutionUnits.Memory)
+		ret.MaxBlockExSteps = intString(p.MaxBlockExecutionUnits.Steps)
+	}
+	if t := p.PoolVotingThresholds; t != nil {
+		ret.PvtMotionNoConfidence = t.MotionNoConfidence
+		ret.PvtCommitteeNormal = t.CommitteeNormal
+		ret.PvtCommitteeNoConfidence = t.CommitteeNoConfidence
+		ret.PvtHardForkInitiation = t.HardForkInitiation
+		ret.PvtPPSecurityGroup = t.PpSecurityGroup
+	}
+	if t := p.DRepVotingThresholds; t != nil {
+		ret.DvtMotionNoConfidence = t.MotionNoConfidence
+		ret.DvtCommitteeNormal = t.CommitteeNormal
+		ret.DvtCommitteeNoConfidence = t.CommitteeNoConfidence
+		ret.DvtUpdateToConstitution = t.UpdateToConstitution
+		ret.DvtHardForkInitiation = t.HardForkInitiation
+		ret.DvtPPNetworkGroup = t.PpNetworkGroup
+		ret.DvtPPEconomicGroup = t.PpEconomicGroup
+		ret.DvtPPTechnicalGroup = t.PpTechnicalGroup
+		ret.DvtPPGovGroup = t.PpGovGroup
+		ret.DvtTreasuryWithdrawal = t.TreasuryWithdrawal
+	}
+	return ret
+}
+
+func uintString(v *uint64) *string {
+	if v == nil {
+		return nil
+	}
+	ret := strconv.FormatUint(*v, 10)
+	return &ret
+}
+
+func intString(v int64) *string {
+	ret := strconv.FormatInt(v, 10)
+	return &ret
+}
+
+// handleProtocolParams godoc
+//
+//	@Summary		Protocol Parameters
+//	@Description	Return the protocol parameters of the current era, queried from the node.
+//	@Description	The default layout matches cardano-cli query protocol-parameters; format=blockfrost returns the Blockfrost epoch parameters layout.
+//	@Description	Parameters are cached until the end of the current epoch.
+//	@Produce		json
+//	@Param			format	query		string	false	"Response layout: cardano-cli (default) or blockfrost"
+//	@Success		200		{object}	submit.ProtocolParams	"Ok"
+//	@Failure		400		{object}	string					"Bad Request"
+//	@Failure		500		{object}	string					"Server Error"
+//	@Router			/api/protocol-parameters [get]
+func handleProtocolParams(w http.ResponseWriter, r *http.Request, cache *protocolParamsCache) {
+	logger := logging.GetLogger()
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "cardano-cli" && format != "blockfrost" {
+		writeJSON(w, http.StatusBadRequest, "invalid format, must be cardano-cli or blockfrost")
+		return
+	}
+	params, err := cache.get()
+	if err != nil {
+		logger.Error("failure getting protocol parameters", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	if format == "blockfrost" {
+		writeJSON(w, http.StatusOK, newBlockfrostProtocolParams(params))
+		return
+	}
+	writeJSON(w, http.StatusOK, params)
+}
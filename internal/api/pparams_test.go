@@ -0,0 +1,183 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func testProtocolParams() *submit.ProtocolParams {
+	return &submit.ProtocolParams{
+		Epoch:               900,
+		TxFeePerByte:        44,
+		TxFeeFixed:          155381,
+		StakeAddressDeposit: 2000000,
+		MinPoolCost:         170000000,
+		UtxoCostPerByte:     uint64Ptr(4310),
+		MaxTxExecutionUnits: &lcommon.ExUnits{Memory: 14000000, Steps: 10000000000},
+		DRepDeposit:         uint64Ptr(500000000),
+	}
+}
+
+func TestProtocolParamsCache_ExpiresAtEpochEnd(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	epochEnd := time.Now().Add(time.Hour)
+	cache := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			calls.Add(1)
+			return testProtocolParams(), nil
+		},
+		epochEnd: func(epoch uint64) (time.Time, error) {
+			if epoch != 900 {
+				t.Errorf("unexpected epoch %d", epoch)
+			}
+			return epochEnd, nil
+		},
+	}
+	for range 3 {
+		if _, err := cache.get(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 node query, got %d", n)
+	}
+	if !cache.expires.Equal(epochEnd) {
+		t.Errorf("expected cache to expire at epoch end %s, got %s", epochEnd, cache.expires)
+	}
+
+	// Crossing the epoch boundary refreshes the parameters
+	cache.expires = time.Now().Add(-time.Second)
+	if _, err := cache.get(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 node queries, got %d", n)
+	}
+}
+
+func TestProtocolParamsCache_MinRefresh(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		epochEnd func(uint64) (time.Time, error)
+	}{
+		{
+			name: "epoch already ended",
+			epochEnd: func(uint64) (time.Time, error) {
+				return time.Now().Add(-time.Minute), nil
+			},
+		},
+		{
+			name: "epoch end unknown",
+			epochEnd: func(uint64) (time.Time, error) {
+				return time.Time{}, errors.New("no slot clock")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cache := &protocolParamsCache{
+				fetch: func() (*submit.ProtocolParams, error) {
+					return testProtocolParams(), nil
+				},
+				epochEnd: tt.epochEnd,
+			}
+			before := time.Now()
+			if _, err := cache.get(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if cache.expires.Before(before.Add(protocolParamsMinRefresh)) {
+				t.Errorf("expected cache to be kept for at least %s", protocolParamsMinRefresh)
+			}
+		})
+	}
+}
+
+func TestHandleProtocolParams_Blockfrost(t *testing.T) {
+	t.Parallel()
+	cache := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return testProtocolParams(), nil
+		},
+		epochEnd: func(uint64) (time.Time, error) {
+			return time.Now().Add(time.Hour), nil
+		},
+	}
+	rec := httptest.NewRecorder()
+	handleProtocolParams(rec, httptest.NewRequest(http.MethodGet, "/api/protocol-parameters?format=blockfrost", nil), cache)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var got map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	want := map[string]any{
+		"epoch":               float64(900),
+		"min_fee_a":           float64(44),
+		"min_fee_b":           float64(155381),
+		"key_deposit":         "2000000",
+		"min_pool_cost":       "170000000",
+		"min_utxo":            "4310",
+		"coins_per_utxo_size": "4310",
+		"max_tx_ex_mem":       "14000000",
+		"max_tx_ex_steps":     "10000000000",
+		"drep_deposit":        "500000000",
+		"coins_per_utxo_word": nil,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: want %v, got %v", k, v, got[k])
+		}
+	}
+}
+
+func TestHandleProtocolParams_Errors(t *testing.T) {
+	t.Parallel()
+	cache := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return nil, errors.New("dial failed")
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	handleProtocolParams(rec, httptest.NewRequest(http.MethodGet, "/api/protocol-parameters?format=xml", nil), cache)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid format, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handleProtocolParams(rec, httptest.NewRequest(http.MethodGet, "/api/protocol-parameters", nil), cache)
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 without a node, got %d", rec.Code)
+	}
+}
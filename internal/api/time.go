@@ -0,0 +1,158 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// checkTxValidity checks the validity interval of a transaction against the
+// cached node tip, and returns an error message if the transaction has
+// expired or is not valid yet. The cached tip can lag the node by up to the
+// cache TTL, so the tip is queried again before refusing a transaction as not
+// valid yet. Transactions are left to the node when the tip can't be queried
+// or the transaction can't be decoded.
+func checkTxValidity(cache *nodeInfoCache, txRawBytes []byte) string {
+	logger := logging.GetLogger()
+	status, _, err := cache.get()
+	if err != nil {
+		logger.Debug("skipping validity interval check", "err", err)
+		return ""
+	}
+	err = submit.CheckValidityInterval(txRawBytes, status.Clock, status.Tip.Slot)
+	var validityErr *submit.ValidityIntervalError
+	if errors.As(err, &validityErr) && !validityErr.Expired {
+		status, _, err = cache.refresh()
+		if err != nil {
+			logger.Debug("skipping validity interval check", "err", err)
+			return ""
+		}
+		err = submit.CheckValidityInterval(txRawBytes, status.Clock, status.Tip.Slot)
+	}
+	if errors.As(err, &validityErr) {
+		return validityErr.Error()
+	}
+	if err != nil {
+		logger.Debug("skipping validity interval check", "err", err)
+	}
+	return ""
+}
+
+type slotTimeResponse struct {
+	Slot  uint64    `json:"slot"`
+	Epoch uint64    `json:"epoch"`
+	Time  time.Time `json:"time"`
+}
+
+// handleSlotTime godoc
+//
+//	@Summary		Slot Time
+//	@Description	Convert a slot number to its wall-clock start time and epoch, using the node era history.
+//	@Produce		json
+//	@Param			slot	path		int					true	"Slot number"
+//	@Success		200		{object}	slotTimeResponse	"Ok"
+//	@Failure		400		{object}	string				"Bad Request"
+//	@Failure		500		{object}	string				"Server Error"
+//	@Router			/api/time/slot/{slot} [get]
+func handleSlotTime(w http.ResponseWriter, r *http.Request, cache *nodeInfoCache) {
+	logger := logging.GetLogger()
+
+	slot, err := strconv.ParseUint(r.PathValue("slot"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, "invalid slot: must be a non-negative integer")
+		return
+	}
+	status, _, err := cache.get()
+	if err != nil {
+		logger.Error("failure getting node info", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	slotTime, err := status.Clock.SlotToTime(slot)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	epoch, err := status.Clock.SlotToEpoch(slot)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, slotTimeResponse{
+		Slot:  slot,
+		Epoch: epoch,
+		Time:  slotTime.UTC(),
+	})
+}
+
+type timeNowResponse struct {
+	Time    time.Time `json:"time"`
+	Slot    uint64    `json:"slot"`
+	Epoch   uint64    `json:"epoch"`
+	TipSlot uint64    `json:"tipSlot"`
+	TipTime time.Time `json:"tipTime"`
+}
+
+// handleTimeNow godoc
+//
+//	@Summary		Current Time
+//	@Description	Return the current wall-clock time with its slot and epoch, along with the slot and time of the node chain tip.
+//	@Description	The tip is cached for a short time and refreshed in the background.
+//	@Produce		json
+//	@Success		200	{object}	timeNowResponse	"Ok"
+//	@Failure		500	{object}	string			"Server Error"
+//	@Router			/api/time/now [get]
+func handleTimeNow(w http.ResponseWriter, _ *http.Request, cache *nodeInfoCache) {
+	logger := logging.GetLogger()
+
+	status, _, err := cache.get()
+	if err != nil {
+		logger.Error("failure getting node info", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	now := time.Now().UTC()
+	slot, err := status.Clock.TimeToSlot(now)
+	if err != nil {
+		logger.Error("failure converting time to slot", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure converting time to slot")
+		return
+	}
+	epoch, err := status.Clock.SlotToEpoch(slot)
+	if err != nil {
+		logger.Error("failure converting slot to epoch", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure converting slot to epoch")
+		return
+	}
+	tipTime, err := status.TipTime()
+	if err != nil {
+		logger.Error("failure converting tip slot to time", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure converting tip slot to time")
+		return
+	}
+	writeJSON(w, http.StatusOK, timeNowResponse{
+		Time:    now,
+		Slot:    slot,
+		Epoch:   epoch,
+		TipSlot: status.Tip.Slot,
+		TipTime: tipTime.UTC(),
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// minimalConwayTxWithTTL returns a Conway tx like minimalConwayTxHex with the
+// given invalid_hereafter slot.
+func minimalConwayTxWithTTL(t *testing.T, ttl uint64) []byte {
+	t.Helper()
+	body := map[uint]any{
+		0: [][]any{{make([]byte, 32), uint32(0)}},
+		1: []map[uint]any{{0: append([]byte{0x60}, make([]byte, 28)...), 1: uint64(1_000_000_000)}},
+		2: uint64(100_000),
+		3: ttl,
+	}
+	txBytes, err := gocbor.Encode([]any{body, map[uint]any{}, true, nil})
+	if err != nil {
+		t.Fatalf("encode tx: %s", err)
+	}
+	return txBytes
+}
+
+// minimalConwayTxWithStart returns a Conway tx like minimalConwayTxHex with
+// the given invalid_before slot.
+func minimalConwayTxWithStart(t *testing.T, start uint64) []byte {
+	t.Helper()
+	body := map[uint]any{
+		0: [][]any{{make([]byte, 32), uint32(0)}},
+		1: []map[uint]any{{0: append([]byte{0x60}, make([]byte, 28)...), 1: uint64(1_000_000_000)}},
+		2: uint64(100_000),
+		8: start,
+	}
+	txBytes, err := gocbor.Encode([]any{body, map[uint]any{}, true, nil})
+	if err != nil {
+		t.Fatalf("encode tx: %s", err)
+	}
+	return txBytes
+}
+
+func TestCheckTxValidity(t *testing.T) {
+	t.Parallel()
+	status := testNodeStatus(t)
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return status, nil
+	})
+	expired := minimalConwayTxWithTTL(t, status.Tip.Slot-299)
+	if msg := checkTxValidity(cache, expired); msg != "transaction expired 300 seconds ago (slot 77759701)" {
+		t.Errorf("unexpected message %q", msg)
+	}
+	if msg := checkTxValidity(cache, minimalConwayTxWithTTL(t, status.Tip.Slot+3600)); msg != "" {
+		t.Errorf("expected valid transaction to pass, got %q", msg)
+	}
+	if msg := checkTxValidity(cache, []byte("not-valid-cbor")); msg != "" {
+		t.Errorf("expected undecodable tx to be left to the node, got %q", msg)
+	}
+	noNode := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return nil, errors.New("dial failed")
+	})
+	if msg := checkTxValidity(noNode, expired); msg != "" {
+		t.Errorf("expected check to be skipped without a node, got %q", msg)
+	}
+}
+
+func TestCheckTxValidity_StaleTip(t *testing.T) {
+	t.Parallel()
+	status := testNodeStatus(t)
+	latest := *status
+	latest.Tip.Slot += 60
+	fetched := []*submit.NodeStatus{status, &latest}
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		next := fetched[0]
+		if len(fetched) > 1 {
+			fetched = fetched[1:]
+		}
+		return next, nil
+	})
+	// Valid against the node tip, but not yet against the cached tip
+	tx := minimalConwayTxWithStart(t, status.Tip.Slot+30)
+	if msg := checkTxValidity(cache, tx); msg != "" {
+		t.Errorf("expected tx to pass against the refreshed tip, got %q", msg)
+	}
+	future := minimalConwayTxWithStart(t, status.Tip.Slot+3600)
+	if msg := checkTxValidity(cache, future); msg != "transaction is not valid for another 3539 seconds (slot 77763600)" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestHandleSlotTime(t *testing.T) {
+	t.Parallel()
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return testNodeStatus(t), nil
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/time/slot/{slot}", func(w http.ResponseWriter, r *http.Request) {
+		handleSlotTime(w, r, cache)
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/time/slot/86401", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp slotTimeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	want := time.Date(2022, time.October, 26, 0, 0, 1, 0, time.UTC)
+	if resp.Slot != 86401 || resp.Epoch != 1 || !resp.Time.Equal(want) {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/time/slot/-1", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid slot, got %d", rec.Code)
+	}
+}
+
+func TestHandleTimeNow(t *testing.T) {
+	t.Parallel()
+	status := testNodeStatus(t)
+	cache := newNodeInfoCache(time.Minute, func() (*submit.NodeStatus, error) {
+		return status, nil
+	})
+	rec := httptest.NewRecorder()
+	handleTimeNow(rec, httptest.NewRequest(http.MethodGet, "/api/time/now", nil), cache)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp timeNowResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	// One-second slots from the system start
+	wantSlot := uint64(resp.Time.Sub(status.Clock.SystemStart) / time.Second)
+	if resp.Slot != wantSlot || resp.Epoch != wantSlot/86400 {
+		t.Errorf("unexpected slot %d / epoch %d for %s", resp.Slot, resp.Epoch, resp.Time)
+	}
+	if resp.TipSlot != status.Tip.Slot || !resp.TipTime.Equal(time.Date(2025, time.April, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected tip: %d at %s", resp.TipSlot, resp.TipTime)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type hashMismatchResponse struct {
+	Error string `json:"error"`
+	submit.TxHashReport
+}
+
+// handleTxHash godoc
+//
+//	@Summary		Tx hash
+//	@Description	Compute the hash of a transaction from its original body bytes, as the node does, without submitting it.
+//	@Description	Also reports whether the body, witness set and auxiliary data are canonical CBOR, and whether the
+//	@Description	auxiliary data hash and script data hash in the body match the contents of the transaction. The script
+//	@Description	data hash is computed with the cost models of the node's protocol parameters. The transaction can be sent
+//	@Description	as CBOR, as hex-encoded CBOR or as a cardano-cli TextEnvelope.
+//	@Accept			application/cbor
+//	@Accept			text/plain
+//	@Accept			application/json
+//	@Produce		json
+//	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor, text/plain, application/json)
+//	@Success		200				{object}	submit.TxHashReport	"Ok"
+//	@Failure		400				{object}	string				"Bad Request"
+//	@Failure		415				{object}	string				"Unsupported Media Type"
+//	@Router			/api/txhash [post]
+func handleTxHash(w http.ResponseWriter, r *http.Request, pparamsCache *protocolParamsCache) {
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	body, ok := readBody(w, r, "application/cbor", "text/plain", "application/json")
+	if !ok {
+		return
+	}
+	txRawBytes, err := submit.ReadTxBytes(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The script data hash cannot be checked without protocol parameters,
+	// which the report says, but the rest of it can
+	pparams, err := pparamsCache.get()
+	if err != nil {
+		logger.Warn("failure getting protocol parameters", "err", err)
+		pparams = nil
+	}
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return submit.ResolveUTxOs(nodeQueryConfig(cfg), inputs)
+	}
+	report, err := submit.HashTx(txRawBytes, pparams, resolve)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// checkTxHashes recomputes the script data hash and auxiliary data hash of a
+// transaction, and returns the report if either does not match the body. The
+// check fails open: if the transaction cannot be decoded, nil is returned, and
+// if the protocol parameters or reference scripts are not available, only the
+// auxiliary data hash is checked and the node remains the final judge on
+// submission.
+func checkTxHashes(cache *protocolParamsCache, txRawBytes []byte, resolve submit.UTxOResolver) *submit.TxHashReport {
+	logger := logging.GetLogger()
+	pparams, err := cache.get()
+	if err != nil {
+		logger.Warn("skipping script data hash check", "err", err)
+		pparams = nil
+	}
+	report, err := submit.HashTx(txRawBytes, pparams, resolve)
+	if err != nil {
+		logger.Warn("failed to check transaction hashes", "err", err)
+		return nil
+	}
+	if check := report.ScriptDataHash; check != nil && check.Error != "" {
+		logger.Warn("skipping script data hash check", "err", check.Error)
+	}
+	if len(report.Mismatches()) == 0 {
+		return nil
+	}
+	return report
+}
+
+// hashMismatchError joins the mismatches of a report into an error message.
+func hashMismatchError(report *submit.TxHashReport) string {
+	return strings.Join(report.Mismatches(), "; ")
+}
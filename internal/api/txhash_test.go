@@ -0,0 +1,140 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// auxDataHashMismatchTx returns a Conway tx like minimalConwayTxHex with an
+// auxiliary data hash in its body but no auxiliary data.
+func auxDataHashMismatchTx(t *testing.T) []byte {
+	t.Helper()
+	body := map[uint]any{
+		0: [][]any{{make([]byte, 32), uint32(0)}},
+		1: []map[uint]any{{0: append([]byte{0x60}, make([]byte, 28)...), 1: uint64(1_000_000_000)}},
+		2: uint64(100_000),
+		7: bytes.Repeat([]byte{0xff}, 32),
+	}
+	txBytes, err := gocbor.Encode([]any{body, map[uint]any{}, true, nil})
+	if err != nil {
+		t.Fatalf("encode tx: %s", err)
+	}
+	return txBytes
+}
+
+func TestHandleTxHash(t *testing.T) {
+	t.Parallel()
+	unreachable := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantCode    int
+	}{
+		{
+			name:        "hex",
+			contentType: "text/plain",
+			body:        minimalConwayTxHex,
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "invalid CBOR",
+			contentType: "application/cbor",
+			body:        "not-valid-cbor",
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			name:        "wrong content type",
+			contentType: "application/octet-stream",
+			body:        minimalConwayTxHex,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodPost, "/api/txhash", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			// Without protocol parameters, everything but the script data
+			// hash is still checked
+			handleTxHash(rec, req, unreachable)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var report submit.TxHashReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatalf("decode response: %s", err)
+			}
+			txBytes, _ := hex.DecodeString(minimalConwayTxHex)
+			want, err := submit.HashTx(txBytes, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if report != *want {
+				t.Errorf("expected %+v, got %+v", *want, report)
+			}
+		})
+	}
+}
+
+func TestCheckTxHashes(t *testing.T) {
+	t.Parallel()
+	minimalTx, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	// The auxiliary data hash is still checked without protocol parameters
+	unreachable := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	if report := checkTxHashes(unreachable, minimalTx, nil); report != nil {
+		t.Errorf("expected tx without hashes to pass, got %+v", report)
+	}
+	report := checkTxHashes(unreachable, auxDataHashMismatchTx(t), nil)
+	if report == nil || report.AuxDataHash == nil || report.AuxDataHash.Match {
+		t.Fatalf("expected auxiliary data hash mismatch, got %+v", report)
+	}
+	want := "body declares auxiliary data hash " + strings.Repeat("ff", 32) +
+		", but the transaction has no auxiliary data"
+	if got := hashMismatchError(report); got != want {
+		t.Errorf("expected error %q, got %q", want, got)
+	}
+	if report := checkTxHashes(unreachable, []byte("not-valid-cbor"), nil); report != nil {
+		t.Errorf("expected undecodable tx to be left to the node, got %+v", report)
+	}
+}
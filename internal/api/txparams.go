@@ -0,0 +1,48 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type paramsViolationResponse struct {
+	Error string `json:"error"`
+	submit.ParamsReport
+}
+
+// checkTxParams checks the collateral and outputs of a transaction against
+// the cached protocol parameters, and returns the report if any limit is
+// broken. The check fails open: if the protocol parameters cannot be queried,
+// the transaction cannot be decoded or its collateral cannot be resolved, nil
+// is returned and the node remains the final judge on submission.
+func checkTxParams(cache *protocolParamsCache, txRawBytes []byte, resolve submit.UTxOResolver) *submit.ParamsReport {
+	logger := logging.GetLogger()
+	pparams, err := cache.get()
+	if err != nil {
+		logger.Warn("skipping protocol parameter checks", "err", err)
+		return nil
+	}
+	report, err := submit.CheckTxParams(txRawBytes, pparams, resolve)
+	if err != nil {
+		logger.Warn("failed to check transaction against protocol parameters", "err", err)
+		return nil
+	}
+	if report.Valid() {
+		return nil
+	}
+	return report
+}
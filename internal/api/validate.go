@@ -0,0 +1,132 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/tx-submit-api/internal/config"
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type validateResponse struct {
+	// Valid is set when every check below passed.
+	Valid         bool                        `json:"valid"`
+	Witnesses     submit.WitnessReport        `json:"witnesses"`
+	NativeScripts []submit.NativeScriptResult `json:"nativeScripts"`
+	Params        submit.ParamsReport         `json:"params"`
+	Hashes        submit.TxHashReport         `json:"hashes"`
+}
+
+type nativeScriptsFailedResponse struct {
+	Error         string                      `json:"error"`
+	NativeScripts []submit.NativeScriptResult `json:"nativeScripts"`
+}
+
+// handleValidateTx godoc
+//
+//	@Summary		Validate Tx
+//	@Description	Run the local pre-submission checks on a transaction without submitting it.
+//	@Description	Verifies the vkey witness signatures and checks for missing witnesses, with the spent inputs resolved from
+//	@Description	the node when input checks are enabled, and evaluates each native script of the witness set.
+//	@Description	Checks the collateral, with the collateral inputs resolved from the node, and the outputs against the
+//	@Description	protocol parameters, and recomputes the script data hash and auxiliary data hash declared in the body.
+//	@Description	Returns 200 with a report whether or not the transaction passes.
+//	@Accept			application/cbor
+//	@Produce		json
+//	@Param			Content-Type	header		string	true	"Content type"	Enums(application/cbor)
+//	@Success		200				{object}	validateResponse	"Ok"
+//	@Failure		400				{object}	string				"Bad Request"
+//	@Failure		415				{object}	string				"Unsupported Media Type"
+//	@Failure		500				{object}	string				"Server Error"
+//	@Router			/api/validate/tx [post]
+func handleValidateTx(w http.ResponseWriter, r *http.Request, pparamsCache *protocolParamsCache) {
+	cfg := config.GetConfig()
+	logger := logging.GetLogger()
+
+	txRawBytes, ok := readCborBody(w, r)
+	if !ok {
+		return
+	}
+
+	nativeScripts, err := submit.EvaluateNativeScripts(txRawBytes)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, "unable to decode transaction: "+err.Error())
+		return
+	}
+	// The checks below share one UTxO query for all the inputs of the tx
+	resolve := submit.ResolveTxUTxOs(txRawBytes, func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return submit.ResolveUTxOs(nodeQueryConfig(cfg), inputs)
+	})
+	var resolveInputs submit.UTxOResolver
+	if cfg.Submit.CheckInputs {
+		resolveInputs = resolve
+	}
+	witnesses, err := submit.VerifyWitnesses(txRawBytes, resolveInputs)
+	if err != nil {
+		logger.Error("failure resolving transaction inputs", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	pparams, err := pparamsCache.get()
+	if err != nil {
+		logger.Error("failure getting protocol parameters", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	params, err := submit.CheckTxParams(txRawBytes, pparams, resolve)
+	if err != nil {
+		logger.Error("failure resolving collateral inputs", "err", err)
+		writeJSON(w, http.StatusInternalServerError, "failure communicating with node")
+		return
+	}
+	hashes, err := submit.HashTx(txRawBytes, pparams, resolve)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, "unable to decode transaction: "+err.Error())
+		return
+	}
+
+	resp := validateResponse{
+		Valid:         witnesses.Valid() && params.Valid() && len(hashes.Mismatches()) == 0,
+		Witnesses:     *witnesses,
+		NativeScripts: nativeScripts,
+		Params:        *params,
+		Hashes:        *hashes,
+	}
+	for _, result := range nativeScripts {
+		resp.Valid = resp.Valid && result.Pass
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// checkTxNativeScripts evaluates the native scripts of a transaction, and
+// returns the results if any script fails. The check fails open: if the
+// transaction cannot be decoded, nil is returned and the node remains the
+// final judge on submission.
+func checkTxNativeScripts(txRawBytes []byte) []submit.NativeScriptResult {
+	results, err := submit.EvaluateNativeScripts(txRawBytes)
+	if err != nil {
+		logging.GetLogger().Warn("failed to evaluate native scripts", "err", err)
+		return nil
+	}
+	for _, result := range results {
+		if !result.Pass {
+			return results
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+// unsignedNativeScriptTx returns a Conway tx like minimalConwayTxHex with a
+// native script witness requiring a signature it does not carry.
+func unsignedNativeScriptTx(t *testing.T) []byte {
+	t.Helper()
+	body := map[uint]any{
+		0: [][]any{{make([]byte, 32), uint32(0)}},
+		1: []map[uint]any{{0: append([]byte{0x60}, make([]byte, 28)...), 1: uint64(1_000_000_000)}},
+		2: uint64(100_000),
+	}
+	witnesses := map[uint]any{1: []any{[]any{uint(0), bytes.Repeat([]byte{0x11}, 28)}}}
+	txBytes, err := gocbor.Encode([]any{body, witnesses, true, nil})
+	if err != nil {
+		t.Fatalf("encode tx: %s", err)
+	}
+	return txBytes
+}
+
+// dustOutputTx returns a Conway tx with an output below the minimum lovelace.
+func dustOutputTx(t *testing.T) []byte {
+	t.Helper()
+	body := map[uint]any{
+		0: [][]any{{make([]byte, 32), uint32(0)}},
+		1: []map[uint]any{{0: append([]byte{0x60}, make([]byte, 28)...), 1: uint64(1_000)}},
+		2: uint64(100_000),
+	}
+	txBytes, err := gocbor.Encode([]any{body, map[uint]any{}, true, nil})
+	if err != nil {
+		t.Fatalf("encode tx: %s", err)
+	}
+	return txBytes
+}
+
+func TestHandleValidateTx(t *testing.T) {
+	t.Parallel()
+	minimalTx, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	cache := &protocolParamsCache{
+		fetch: func() (*submit.ProtocolParams, error) {
+			return testProtocolParams(), nil
+		},
+		epochEnd: func(uint64) (time.Time, error) {
+			return time.Now().Add(time.Hour), nil
+		},
+	}
+	tests := []struct {
+		name       string
+		txBytes    []byte
+		wantValid  bool
+		wantScript bool
+		wantParams []string
+	}{
+		{"no scripts", minimalTx, true, false, nil},
+		{"failing native script", unsignedNativeScriptTx(t), false, true, nil},
+		{"output below min UTxO", dustOutputTx(t), false, false, []string{"min_utxo"}},
+		{"auxiliary data hash mismatch", auxDataHashMismatchTx(t), false, false, nil},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/validate/tx", bytes.NewReader(tt.txBytes))
+		req.Header.Set("Content-Type", "application/cbor")
+		handleValidateTx(rec, req, cache)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", tt.name, rec.Code, rec.Body.String())
+		}
+		var resp validateResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: failed to decode response: %s", tt.name, err)
+		}
+		if resp.Valid != tt.wantValid {
+			t.Errorf("%s: want valid=%t, got %t", tt.name, tt.wantValid, resp.Valid)
+		}
+		if tt.wantScript && (len(resp.NativeScripts) != 1 || resp.NativeScripts[0].Pass ||
+			resp.NativeScripts[0].MissingSigners[0] != hex.EncodeToString(bytes.Repeat([]byte{0x11}, 28))) {
+			t.Errorf("%s: unexpected native script results: %+v", tt.name, resp.NativeScripts)
+		}
+		var gotParams []string
+		for _, v := range resp.Params.Violations {
+			gotParams = append(gotParams, v.Check)
+		}
+		if !slices.Equal(gotParams, tt.wantParams) {
+			t.Errorf("%s: want params violations %v, got %v", tt.name, tt.wantParams, gotParams)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/validate/tx", bytes.NewReader([]byte("not-valid-cbor")))
+	req.Header.Set("Content-Type", "application/cbor")
+	handleValidateTx(rec, req, cache)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid CBOR, got %d", rec.Code)
+	}
+}
+
+func TestCheckTxNativeScripts(t *testing.T) {
+	t.Parallel()
+	minimalTx, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	if results := checkTxNativeScripts(minimalTx); results != nil {
+		t.Errorf("expected tx without scripts to pass, got %+v", results)
+	}
+	if results := checkTxNativeScripts(unsignedNativeScriptTx(t)); len(results) != 1 || results[0].Pass {
+		t.Errorf("expected failing native script, got %+v", results)
+	}
+	if results := checkTxNativeScripts([]byte("not-valid-cbor")); results != nil {
+		t.Errorf("expected undecodable tx to be left to the node, got %+v", results)
+	}
+}
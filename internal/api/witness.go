@@ -0,0 +1,42 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/blinklabs-io/tx-submit-api/internal/logging"
+	"github.com/blinklabs-io/tx-submit-api/submit"
+)
+
+type invalidWitnessesResponse struct {
+	Error string `json:"error"`
+	submit.WitnessReport
+}
+
+// checkTxWitnesses verifies the vkey witnesses of a transaction, and returns
+// the report if any signature is invalid or any witness is missing. Spent
+// inputs are only checked when resolve is non-nil. The check fails open: if
+// the transaction cannot be decoded or the node cannot be queried, nil is
+// returned and the node remains the final judge on submission.
+func checkTxWitnesses(txRawBytes []byte, resolve submit.UTxOResolver) *submit.WitnessReport {
+	report, err := submit.VerifyWitnesses(txRawBytes, resolve)
+	if err != nil {
+		logging.GetLogger().Warn("failed to check transaction witnesses", "err", err)
+		return nil
+	}
+	if report.Valid() {
+		return nil
+	}
+	return report
+}
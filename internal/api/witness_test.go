@@ -0,0 +1,59 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+)
+
+func TestCheckTxWitnesses(t *testing.T) {
+	t.Parallel()
+	txBytes, err := hex.DecodeString(minimalConwayTxHex)
+	if err != nil {
+		t.Fatalf("hex decode failed: %s", err)
+	}
+	if report := checkTxWitnesses(txBytes, nil); report != nil {
+		t.Errorf("expected tx without required signers to pass, got %s", report.String())
+	}
+
+	signer := bytes.Repeat([]byte{0xb1}, 28)
+	body := map[uint]any{
+		0:  [][]any{{make([]byte, 32), uint32(0)}},
+		1:  []map[uint]any{{0: append([]byte{0x60}, make([]byte, 28)...), 1: uint64(1_000_000_000)}},
+		2:  uint64(100_000),
+		14: [][]byte{signer},
+	}
+	unsigned, err := gocbor.Encode([]any{body, map[uint]any{}, true, nil})
+	if err != nil {
+		t.Fatalf("encode tx: %s", err)
+	}
+	report := checkTxWitnesses(unsigned, nil)
+	if report == nil || len(report.MissingWitnesses) != 1 ||
+		report.MissingWitnesses[0].KeyHash != hex.EncodeToString(signer) {
+		t.Fatalf("expected missing required signer witness, got %+v", report)
+	}
+	if !strings.Contains(report.String(), hex.EncodeToString(signer)) {
+		t.Errorf("expected message to name the key hash, got %q", report.String())
+	}
+
+	if report := checkTxWitnesses([]byte("not-valid-cbor"), nil); report != nil {
+		t.Errorf("expected undecodable tx to be left to the node, got %+v", report)
+	}
+}
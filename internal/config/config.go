@@ -20,6 +20,8 @@ import (
 	"os"
 
 	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/tx-submit-api/internal/metrics"
+	"github.com/blinklabs-io/tx-submit-api/submit"
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -30,6 +32,8 @@ type Config struct {
 	Metrics MetricsConfig `yaml:"metrics"`
 	Debug   DebugConfig   `yaml:"debug"`
 	Node    NodeConfig    `yaml:"node"`
+	Mempool MempoolConfig `yaml:"mempool"`
+	Submit  SubmitConfig  `yaml:"submit"`
 	Tls     TlsConfig     `yaml:"tls"`
 }
 
@@ -50,8 +54,12 @@ type DebugConfig struct {
 }
 
 type MetricsConfig struct {
-	ListenAddress string `yaml:"address" envconfig:"METRICS_LISTEN_ADDRESS"`
-	ListenPort    uint   `yaml:"port"    envconfig:"METRICS_LISTEN_PORT"`
+	ListenAddress   string                            `yaml:"address"         envconfig:"METRICS_LISTEN_ADDRESS"`
+	ListenPort      uint                              `yaml:"port"            envconfig:"METRICS_LISTEN_PORT"`
+	Attribution     map[string]submit.AttributionRule `yaml:"attribution"     ignored:"true"`
+	DurationBuckets []float64                         `yaml:"durationBuckets" envconfig:"METRICS_DURATION_BUCKETS"`
+	TxSizeBuckets   []float64                         `yaml:"txSizeBuckets"   envconfig:"METRICS_TX_SIZE_BUCKETS"`
+	TxFeeBuckets    []float64                         `yaml:"txFeeBuckets"    envconfig:"METRICS_TX_FEE_BUCKETS"`
 }
 
 type NodeConfig struct {
@@ -63,6 +71,31 @@ type NodeConfig struct {
 	SocketPath          string `yaml:"socketPath"           envconfig:"CARDANO_NODE_SOCKET_PATH"`
 	Timeout             uint   `yaml:"timeout"              envconfig:"CARDANO_NODE_SOCKET_TIMEOUT"`
 	HealthCheckInterval uint   `yaml:"healthCheckInterval"  envconfig:"CARDANO_NODE_HEALTH_CHECK_INTERVAL"`
+	MaxTipLag           uint   `yaml:"maxTipLag"            envconfig:"CARDANO_NODE_MAX_TIP_LAG"`
+	InfoCacheTTL        uint   `yaml:"infoCacheTtl"         envconfig:"CARDANO_NODE_INFO_CACHE_TTL"`
+	ShelleyGenesisFile  string `yaml:"shelleyGenesisFile"   envconfig:"CARDANO_SHELLEY_GENESIS_FILE"`
+}
+
+type MempoolConfig struct {
+	MaxConcurrentQueries uint    `yaml:"maxConcurrentQueries" envconfig:"MEMPOOL_MAX_CONCURRENT_QUERIES"`
+	DefaultPageSize      uint    `yaml:"defaultPageSize"      envconfig:"MEMPOOL_DEFAULT_PAGE_SIZE"`
+	MaxPageSize          uint    `yaml:"maxPageSize"          envconfig:"MEMPOOL_MAX_PAGE_SIZE"`
+	MetricsInterval      uint    `yaml:"metricsInterval"      envconfig:"MEMPOOL_METRICS_INTERVAL"`
+	AdmissionThreshold   float64 `yaml:"admissionThreshold"   envconfig:"MEMPOOL_ADMISSION_THRESHOLD"`
+}
+
+type SubmitConfig struct {
+	CheckEra             bool               `yaml:"checkEra"             envconfig:"SUBMIT_CHECK_ERA"`
+	CheckHashes          bool               `yaml:"checkHashes"          envconfig:"SUBMIT_CHECK_HASHES"`
+	CheckInputs          bool               `yaml:"checkInputs"          envconfig:"SUBMIT_CHECK_INPUTS"`
+	CheckNativeScripts   bool               `yaml:"checkNativeScripts"   envconfig:"SUBMIT_CHECK_NATIVE_SCRIPTS"`
+	CheckNetwork         bool               `yaml:"checkNetwork"         envconfig:"SUBMIT_CHECK_NETWORK"`
+	CheckParams          bool               `yaml:"checkParams"          envconfig:"SUBMIT_CHECK_PARAMS"`
+	CheckValidity        bool               `yaml:"checkValidity"        envconfig:"SUBMIT_CHECK_VALIDITY"`
+	CheckWitnesses       bool               `yaml:"checkWitnesses"       envconfig:"SUBMIT_CHECK_WITNESSES"`
+	Policy               submit.PolicyRules `yaml:"policy"`
+	PolicyFile           string             `yaml:"policyFile"           envconfig:"SUBMIT_POLICY_FILE"`
+	PolicyReloadInterval uint               `yaml:"policyReloadInterval" envconfig:"SUBMIT_POLICY_RELOAD_INTERVAL"`
 }
 
 type TlsConfig struct {
@@ -85,14 +118,34 @@ var globalConfig = &Config{
 		ListenPort:    0,
 	},
 	Metrics: MetricsConfig{
-		ListenAddress: "",
-		ListenPort:    8081,
+		ListenAddress:   "",
+		ListenPort:      8081,
+		DurationBuckets: metrics.DefaultDurationBuckets,
+		TxSizeBuckets:   metrics.DefaultTxSizeBuckets,
+		TxFeeBuckets:    metrics.DefaultTxFeeBuckets,
 	},
 	Node: NodeConfig{
 		Network:             "mainnet",
 		SocketPath:          "/node-ipc/node.socket",
 		Timeout:             30,
 		HealthCheckInterval: 30,
+		MaxTipLag:           300,
+		InfoCacheTTL:        10,
+	},
+	Mempool: MempoolConfig{
+		MaxConcurrentQueries: 2,
+		DefaultPageSize:      100,
+		MaxPageSize:          500,
+		MetricsInterval:      30,
+	},
+	Submit: SubmitConfig{
+		CheckEra:             true,
+		CheckHashes:          true,
+		CheckNativeScripts:   true,
+		CheckNetwork:         true,
+		CheckValidity:        true,
+		CheckWitnesses:       true,
+		PolicyReloadInterval: 10,
 	},
 }
 
@@ -118,6 +171,9 @@ func Load(configFile string) (*Config, error) {
 	if err := globalConfig.populateNetworkMagic(); err != nil {
 		return nil, err
 	}
+	if err := globalConfig.checkMempool(); err != nil {
+		return nil, err
+	}
 	if err := globalConfig.checkNode(); err != nil {
 		return nil, err
 	}
@@ -143,6 +199,13 @@ func (c *Config) populateNetworkMagic() error {
 	return nil
 }
 
+func (c *Config) checkMempool() error {
+	if c.Mempool.AdmissionThreshold < 0 || c.Mempool.AdmissionThreshold > 1 {
+		return fmt.Errorf("invalid mempool admission threshold: %g (must be between 0 and 1)", c.Mempool.AdmissionThreshold)
+	}
+	return nil
+}
+
 func (c *Config) checkNode() error {
 	if c.Node.SkipCheck {
 		return nil
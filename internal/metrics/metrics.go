@@ -15,8 +15,12 @@
 package metrics
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -31,8 +35,46 @@ var (
 	txSubmitScriptTypeTotal         *prometheus.CounterVec
 	txSubmitHasMintingTotal         *prometheus.CounterVec
 	txSubmitHasReferenceInputsTotal *prometheus.CounterVec
+	txSubmitInputCheckTotal         *prometheus.CounterVec
+	txSubmitMissingInputsTotal      *prometheus.CounterVec
+	txSubmitPolicyDeniedTotal       *prometheus.CounterVec
+	txSubmitPolicyReloadTotal       *prometheus.CounterVec
+	txSubmitAdmissionExprTotal      *prometheus.CounterVec
+
+	// Conway governance content of submitted transactions.
+	txSubmitVotesTotal               *prometheus.CounterVec
+	txSubmitProposalsTotal           *prometheus.CounterVec
+	txSubmitGovCertificatesTotal     *prometheus.CounterVec
+	txSubmitTreasuryDonationsTotal   *prometheus.CounterVec
+	txSubmitTreasuryDonationLovelace *prometheus.CounterVec
+
+	// Certificates and reward withdrawals of submitted transactions.
+	txSubmitCertificatesTotal  *prometheus.CounterVec
+	txSubmitWithdrawalsTotal   *prometheus.CounterVec
+	txSubmitWithdrawalLovelace *prometheus.CounterVec
+
+	// Submit path latency by result and node endpoint, and the size and fee
+	// of submitted transactions.
+	txSubmitRequestDuration   *prometheus.HistogramVec
+	txSubmitParseDuration     *prometheus.HistogramVec
+	nodeDialDuration          *prometheus.HistogramVec
+	nodeLocalTxSubmitDuration *prometheus.HistogramVec
+	nodeHasTxDuration         *prometheus.HistogramVec
+	txSubmitTxSizeBytes       *prometheus.HistogramVec
+	txSubmitTxFeeLovelace     *prometheus.HistogramVec
+
+	// Node mempool gauges, refreshed by the background mempool collector.
+	mempoolCapacityBytes   prometheus.Gauge
+	mempoolSizeBytes       prometheus.Gauge
+	mempoolTxCount         prometheus.Gauge
+	mempoolOwnPendingTxs   prometheus.Gauge
+	mempoolOwnPendingRatio prometheus.Gauge
+
+	// Current era of the node, refreshed with the cached node info.
+	nodeEra *prometheus.GaugeVec
 
 	registerOnce sync.Once
+	registered   atomic.Bool
 )
 
 func init() {
@@ -53,35 +95,252 @@ func initVars() {
 			Name: "tx_submit_requests_total",
 			Help: "Total transaction submission requests by result.",
 		},
-		[]string{"result"},
+		[]string{"result", "dapp"},
 	)
 	txSubmitScriptTypeTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "tx_submit_script_type_total",
 			Help: "Transaction submissions by script type present in witness set.",
 		},
-		[]string{"type"},
+		[]string{"type", "dapp"},
 	)
 	txSubmitHasMintingTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "tx_submit_has_minting_total",
 			Help: "Transaction submissions by minting or burning presence.",
 		},
-		[]string{"has_minting"},
+		[]string{"has_minting", "dapp"},
 	)
 	txSubmitHasReferenceInputsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "tx_submit_has_reference_inputs_total",
 			Help: "Transaction submissions by reference input presence.",
 		},
-		[]string{"has_reference_inputs"},
+		[]string{"has_reference_inputs", "dapp"},
+	)
+	txSubmitInputCheckTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_input_check_total",
+			Help: "Pre-submission UTxO input checks by result.",
+		},
+		[]string{"result"},
+	)
+	txSubmitMissingInputsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_missing_inputs_total",
+			Help: "Transaction inputs missing from the node UTxO set by input kind.",
+		},
+		[]string{"kind"},
+	)
+	txSubmitPolicyDeniedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_policy_denied_total",
+			Help: "Transactions refused by the admission policy by matched rule.",
+		},
+		[]string{"rule"},
+	)
+	txSubmitPolicyReloadTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_policy_reload_total",
+			Help: "Admission policy rules file reloads by result.",
+		},
+		[]string{"result"},
+	)
+	txSubmitAdmissionExprTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_admission_expression_total",
+			Help: "Admission expression evaluations by expression name and result.",
+		},
+		[]string{"expression", "result"},
+	)
+	txSubmitVotesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_votes_total",
+			Help: "Voting procedures in transaction submissions by voter type.",
+		},
+		[]string{"voter_type", "dapp"},
+	)
+	txSubmitProposalsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_proposals_total",
+			Help: "Proposal procedures in transaction submissions by governance action type.",
+		},
+		[]string{"action_type", "dapp"},
+	)
+	txSubmitGovCertificatesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_governance_certificates_total",
+			Help: "DRep registration and update and vote delegation certificates in transaction submissions by type.",
+		},
+		[]string{"type", "dapp"},
+	)
+	txSubmitTreasuryDonationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_treasury_donations_total",
+			Help: "Transaction submissions with a treasury donation.",
+		},
+		[]string{"dapp"},
+	)
+	txSubmitTreasuryDonationLovelace = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_treasury_donation_lovelace_total",
+			Help: "Lovelace donated to the treasury by transaction submissions.",
+		},
+		[]string{"dapp"},
+	)
+	txSubmitCertificatesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_certificates_total",
+			Help: "Certificates in transaction submissions by category.",
+		},
+		[]string{"category", "dapp"},
+	)
+	txSubmitWithdrawalsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_withdrawals_total",
+			Help: "Reward withdrawals in transaction submissions.",
+		},
+		[]string{"dapp"},
+	)
+	txSubmitWithdrawalLovelace = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_submit_withdrawal_lovelace_total",
+			Help: "Lovelace withdrawn from reward accounts by transaction submissions.",
+		},
+		[]string{"dapp"},
+	)
+	mempoolCapacityBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tx_submit_mempool_capacity_bytes",
+		Help: "Capacity of the node mempool in bytes.",
+	})
+	mempoolSizeBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tx_submit_mempool_size_bytes",
+		Help: "Size of the transactions in the node mempool in bytes.",
+	})
+	mempoolTxCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tx_submit_mempool_tx_count",
+		Help: "Number of transactions in the node mempool.",
+	})
+	mempoolOwnPendingTxs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tx_submit_mempool_own_pending_txs",
+		Help: "Transactions accepted through this API that are still in the node mempool.",
+	})
+	mempoolOwnPendingRatio = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tx_submit_mempool_own_pending_ratio",
+		Help: "Share of node mempool transactions that were accepted through this API.",
+	})
+	nodeEra = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tx_submit_node_era",
+			Help: "Current era of the node, set to 1 for the era label of the current era.",
+		},
+		[]string{"era"},
+	)
+	initHistograms(DefaultDurationBuckets, DefaultTxSizeBuckets, DefaultTxFeeBuckets)
+}
+
+// Default histogram buckets, used unless others are set with
+// ConfigureHistograms.
+var (
+	// DefaultDurationBuckets are in seconds, from 5ms to 30s.
+	DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	// DefaultTxSizeBuckets are in bytes, up to the 16KiB transaction size
+	// limit.
+	DefaultTxSizeBuckets = []float64{256, 512, 1024, 2048, 4096, 8192, 12288, 16384}
+	// DefaultTxFeeBuckets are in lovelace, from 0.17 to 5 ADA.
+	DefaultTxFeeBuckets = []float64{170_000, 200_000, 250_000, 300_000, 400_000, 500_000, 750_000, 1_000_000, 2_000_000, 5_000_000}
+)
+
+func initHistograms(durationBuckets, txSizeBuckets, txFeeBuckets []float64) {
+	durationHistogram := func(name, help string, labels ...string) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    name,
+				Help:    help,
+				Buckets: durationBuckets,
+			},
+			append([]string{"result", "node"}, labels...),
+		)
+	}
+	txSubmitRequestDuration = durationHistogram(
+		"tx_submit_request_duration_seconds",
+		"Time taken to handle transaction submission requests by result, node endpoint and dApp.",
+		"dapp",
+	)
+	txSubmitParseDuration = durationHistogram(
+		"tx_submit_parse_duration_seconds",
+		"Time taken to parse the CBOR of submitted transactions by result and node endpoint.",
+	)
+	nodeDialDuration = durationHistogram(
+		"tx_submit_node_dial_duration_seconds",
+		"Time taken to dial the node and complete the handshake by result and node endpoint.",
+	)
+	nodeLocalTxSubmitDuration = durationHistogram(
+		"tx_submit_node_local_tx_submission_duration_seconds",
+		"Round-trip time of LocalTxSubmission requests by result and node endpoint.",
+	)
+	nodeHasTxDuration = durationHistogram(
+		"tx_submit_node_has_tx_duration_seconds",
+		"Time taken by LocalTxMonitor HasTx queries by result and node endpoint.",
+	)
+	txSubmitTxSizeBytes = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "tx_submit_tx_size_bytes",
+			Help:    "Size of submitted transactions in bytes.",
+			Buckets: txSizeBuckets,
+		},
+		[]string{"dapp"},
 	)
+	txSubmitTxFeeLovelace = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "tx_submit_tx_fee_lovelace",
+			Help:    "Fee of submitted transactions in lovelace.",
+			Buckets: txFeeBuckets,
+		},
+		[]string{"dapp"},
+	)
+}
+
+// ConfigureHistograms sets the buckets of the duration histograms, in
+// seconds, and of the transaction size and fee histograms. An empty list keeps
+// the default buckets. It must be called before Register.
+func ConfigureHistograms(durationBuckets, txSizeBuckets, txFeeBuckets []float64) error {
+	if registered.Load() {
+		return errors.New("histograms must be configured before the metrics are registered")
+	}
+	var err error
+	if durationBuckets, err = checkBuckets("duration", durationBuckets, DefaultDurationBuckets); err != nil {
+		return err
+	}
+	if txSizeBuckets, err = checkBuckets("tx size", txSizeBuckets, DefaultTxSizeBuckets); err != nil {
+		return err
+	}
+	if txFeeBuckets, err = checkBuckets("tx fee", txFeeBuckets, DefaultTxFeeBuckets); err != nil {
+		return err
+	}
+	initHistograms(durationBuckets, txSizeBuckets, txFeeBuckets)
+	return nil
+}
+
+// checkBuckets returns buckets, or defaults if it is empty, and checks that
+// the buckets are in increasing order.
+func checkBuckets(name string, buckets, defaults []float64) ([]float64, error) {
+	if len(buckets) == 0 {
+		return defaults, nil
+	}
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return nil, fmt.Errorf("%s buckets must be in increasing order", name)
+		}
+	}
+	return buckets, nil
 }
 
 // Register registers all collectors with the default Prometheus registry.
 // Safe to call multiple times; registration happens exactly once.
 func Register() {
 	registerOnce.Do(func() {
+		registered.Store(true)
 		prometheus.MustRegister(
 			txSubmitFailCount,
 			txSubmitCount,
@@ -89,6 +348,32 @@ func Register() {
 			txSubmitScriptTypeTotal,
 			txSubmitHasMintingTotal,
 			txSubmitHasReferenceInputsTotal,
+			txSubmitInputCheckTotal,
+			txSubmitMissingInputsTotal,
+			txSubmitPolicyDeniedTotal,
+			txSubmitPolicyReloadTotal,
+			txSubmitAdmissionExprTotal,
+			txSubmitVotesTotal,
+			txSubmitProposalsTotal,
+			txSubmitGovCertificatesTotal,
+			txSubmitTreasuryDonationsTotal,
+			txSubmitTreasuryDonationLovelace,
+			txSubmitCertificatesTotal,
+			txSubmitWithdrawalsTotal,
+			txSubmitWithdrawalLovelace,
+			mempoolCapacityBytes,
+			mempoolSizeBytes,
+			mempoolTxCount,
+			mempoolOwnPendingTxs,
+			mempoolOwnPendingRatio,
+			nodeEra,
+			txSubmitRequestDuration,
+			txSubmitParseDuration,
+			nodeDialDuration,
+			nodeLocalTxSubmitDuration,
+			nodeHasTxDuration,
+			txSubmitTxSizeBytes,
+			txSubmitTxFeeLovelace,
 		)
 	})
 }
@@ -110,17 +395,148 @@ func IncTxSubmitFailCount() {
 }
 
 // RecordTxRequest records a submission attempt. result is one of "accepted",
-// "rejected" (node rejected the tx), or "error".
-func RecordTxRequest(result string) {
-	txSubmitRequestsTotal.WithLabelValues(result).Inc()
+// "rejected" (node rejected the tx), "throttled" (refused because the node
+// mempool is near capacity), "missing_inputs" (refused because inputs are not
+// in the node UTxO set), "policy_denied" (refused by the admission policy),
+// "wrong_network" (refused because it is for another network),
+// "outside_validity" (refused because the tip is outside its validity
+// interval), "invalid_witnesses" (refused for invalid signatures or missing
+// witnesses), "native_script_failed" (refused because a native script fails),
+// "params_violation" (refused for bad collateral or outputs breaking protocol
+// parameter limits), "hash_mismatch" (refused because its script data hash or
+// auxiliary data hash does not match its contents),
+// "wrong_era" (refused because it is not encoded for the node's current era),
+// or "error". dapp is the dApp the transaction is attributed to, or "none".
+func RecordTxRequest(result, dapp string) {
+	txSubmitRequestsTotal.WithLabelValues(result, dapp).Inc()
 }
 
 // RecordTxContent records content signals for a successfully parsed transaction.
 // Call after ParseTxInfo succeeds, regardless of whether the node accepted the tx.
-func RecordTxContent(scriptType string, hasMinting, hasReferenceInputs bool) {
-	txSubmitScriptTypeTotal.WithLabelValues(scriptType).Inc()
-	txSubmitHasMintingTotal.WithLabelValues(strconv.FormatBool(hasMinting)).Inc()
-	txSubmitHasReferenceInputsTotal.WithLabelValues(strconv.FormatBool(hasReferenceInputs)).Inc()
+func RecordTxContent(dapp, scriptType string, hasMinting, hasReferenceInputs bool) {
+	txSubmitScriptTypeTotal.WithLabelValues(scriptType, dapp).Inc()
+	txSubmitHasMintingTotal.WithLabelValues(strconv.FormatBool(hasMinting), dapp).Inc()
+	txSubmitHasReferenceInputsTotal.WithLabelValues(strconv.FormatBool(hasReferenceInputs), dapp).Inc()
+}
+
+// RecordTxGovernance records the governance content of a successfully parsed
+// transaction: its votes by voter type, its proposals by governance action
+// type, its governance certificates by type and its treasury donation in
+// lovelace, if any. Call alongside RecordTxContent.
+func RecordTxGovernance(dapp string, votes, proposals, certificates map[string]int, donation uint64) {
+	for voterType, count := range votes {
+		txSubmitVotesTotal.WithLabelValues(voterType, dapp).Add(float64(count))
+	}
+	for actionType, count := range proposals {
+		txSubmitProposalsTotal.WithLabelValues(actionType, dapp).Add(float64(count))
+	}
+	for certType, count := range certificates {
+		txSubmitGovCertificatesTotal.WithLabelValues(certType, dapp).Add(float64(count))
+	}
+	if donation > 0 {
+		txSubmitTreasuryDonationsTotal.WithLabelValues(dapp).Inc()
+		txSubmitTreasuryDonationLovelace.WithLabelValues(dapp).Add(float64(donation))
+	}
+}
+
+// RecordTxStaking records the certificates of a successfully parsed
+// transaction by category, and its reward withdrawals with the total
+// withdrawn in lovelace. Call alongside RecordTxContent.
+func RecordTxStaking(dapp string, certificates map[string]int, withdrawals int, withdrawalAmount uint64) {
+	for category, count := range certificates {
+		txSubmitCertificatesTotal.WithLabelValues(category, dapp).Add(float64(count))
+	}
+	if withdrawals > 0 {
+		txSubmitWithdrawalsTotal.WithLabelValues(dapp).Add(float64(withdrawals))
+		txSubmitWithdrawalLovelace.WithLabelValues(dapp).Add(float64(withdrawalAmount))
+	}
+}
+
+// RecordTxSize records the size in bytes and the fee in lovelace of a
+// successfully parsed transaction. Call alongside RecordTxContent.
+func RecordTxSize(dapp string, size int, fee uint64) {
+	txSubmitTxSizeBytes.WithLabelValues(dapp).Observe(float64(size))
+	txSubmitTxFeeLovelace.WithLabelValues(dapp).Observe(float64(fee))
+}
+
+// ObserveSubmitRequest records the time taken to handle a transaction
+// submission request, with the same result and dApp as RecordTxRequest, for
+// the node at endpoint.
+func ObserveSubmitRequest(result, endpoint, dapp string, duration time.Duration) {
+	txSubmitRequestDuration.WithLabelValues(result, endpoint, dapp).Observe(duration.Seconds())
+}
+
+// ObserveTxParse records the time taken to parse the CBOR of a submitted
+// transaction, with result "ok" or "error".
+func ObserveTxParse(result, endpoint string, duration time.Duration) {
+	txSubmitParseDuration.WithLabelValues(result, endpoint).Observe(duration.Seconds())
+}
+
+// ObserveNodeDial records the time taken to dial the node at endpoint and
+// complete the handshake, with result "ok" or "error".
+func ObserveNodeDial(result, endpoint string, duration time.Duration) {
+	nodeDialDuration.WithLabelValues(result, endpoint).Observe(duration.Seconds())
+}
+
+// ObserveLocalTxSubmission records the round-trip time of a LocalTxSubmission
+// request, with result "accepted", "rejected" or "error".
+func ObserveLocalTxSubmission(result, endpoint string, duration time.Duration) {
+	nodeLocalTxSubmitDuration.WithLabelValues(result, endpoint).Observe(duration.Seconds())
+}
+
+// ObserveHasTx records the time taken by a HasTx query, with result "found",
+// "not_found" or "error".
+func ObserveHasTx(result, endpoint string, duration time.Duration) {
+	nodeHasTxDuration.WithLabelValues(result, endpoint).Observe(duration.Seconds())
+}
+
+// RecordInputCheck records the result of a pre-submission UTxO input check,
+// one of "ok", "missing_inputs" or "error", along with the kinds of the
+// missing inputs ("input", "collateral" or "reference").
+func RecordInputCheck(result string, missingKinds []string) {
+	txSubmitInputCheckTotal.WithLabelValues(result).Inc()
+	for _, kind := range missingKinds {
+		txSubmitMissingInputsTotal.WithLabelValues(kind).Inc()
+	}
+}
+
+// RecordPolicyDenied records a transaction refused by the admission policy
+// rule, such as "addresses.deny".
+func RecordPolicyDenied(rule string) {
+	txSubmitPolicyDeniedTotal.WithLabelValues(rule).Inc()
+}
+
+// RecordPolicyReload records a reload of the admission policy rules file,
+// either "ok" or "error".
+func RecordPolicyReload(result string) {
+	txSubmitPolicyReloadTotal.WithLabelValues(result).Inc()
+}
+
+// RecordAdmissionExpression records the evaluation of a named admission
+// expression, with a result of "pass", "fail" or "error".
+func RecordAdmissionExpression(name, result string) {
+	txSubmitAdmissionExprTotal.WithLabelValues(name, result).Inc()
+}
+
+// RecordMempool records a node mempool snapshot. ownPending is the number of
+// transactions accepted through this API that are still in the snapshot.
+func RecordMempool(capacityBytes, sizeBytes, txCount uint32, ownPending int) {
+	mempoolCapacityBytes.Set(float64(capacityBytes))
+	mempoolSizeBytes.Set(float64(sizeBytes))
+	mempoolTxCount.Set(float64(txCount))
+	mempoolOwnPendingTxs.Set(float64(ownPending))
+	ratio := 0.0
+	if txCount > 0 {
+		ratio = float64(ownPending) / float64(txCount)
+	}
+	mempoolOwnPendingRatio.Set(ratio)
+}
+
+// RecordNodeEra records the current era of the node. Only the current era
+// is kept, so a hard fork shows as the series of the old era ending.
+func RecordNodeEra(era string) {
+	nodeEra.Reset()
+	nodeEra.WithLabelValues(era).Set(1)
 }
 
 // Getters used by tests in other packages.
@@ -140,3 +556,27 @@ func TxSubmitHasMintingTotal() *prometheus.CounterVec {
 func TxSubmitHasReferenceInputsTotal() *prometheus.CounterVec {
 	return txSubmitHasReferenceInputsTotal
 }
+
+func TxSubmitInputCheckTotal() *prometheus.CounterVec {
+	return txSubmitInputCheckTotal
+}
+
+func TxSubmitMissingInputsTotal() *prometheus.CounterVec {
+	return txSubmitMissingInputsTotal
+}
+
+func TxSubmitPolicyDeniedTotal() *prometheus.CounterVec {
+	return txSubmitPolicyDeniedTotal
+}
+
+func TxSubmitPolicyReloadTotal() *prometheus.CounterVec {
+	return txSubmitPolicyReloadTotal
+}
+
+func TxSubmitAdmissionExpressionTotal() *prometheus.CounterVec {
+	return txSubmitAdmissionExprTotal
+}
+
+func NodeEra() *prometheus.GaugeVec {
+	return nodeEra
+}
@@ -15,8 +15,11 @@
 package metrics
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
@@ -27,54 +30,54 @@ func setup() {
 
 func TestRecordTxRequest_Accepted(t *testing.T) {
 	setup()
-	RecordTxRequest("accepted")
-	if got := testutil.ToFloat64(txSubmitRequestsTotal.WithLabelValues("accepted")); got != 1 {
+	RecordTxRequest("accepted", "none")
+	if got := testutil.ToFloat64(txSubmitRequestsTotal.WithLabelValues("accepted", "none")); got != 1 {
 		t.Errorf("expected 1, got %f", got)
 	}
 }
 
 func TestRecordTxRequest_Rejected(t *testing.T) {
 	setup()
-	RecordTxRequest("rejected")
-	if got := testutil.ToFloat64(txSubmitRequestsTotal.WithLabelValues("rejected")); got != 1 {
+	RecordTxRequest("rejected", "none")
+	if got := testutil.ToFloat64(txSubmitRequestsTotal.WithLabelValues("rejected", "none")); got != 1 {
 		t.Errorf("expected 1, got %f", got)
 	}
 }
 
 func TestRecordTxRequest_Error(t *testing.T) {
 	setup()
-	RecordTxRequest("error")
-	if got := testutil.ToFloat64(txSubmitRequestsTotal.WithLabelValues("error")); got != 1 {
+	RecordTxRequest("error", "none")
+	if got := testutil.ToFloat64(txSubmitRequestsTotal.WithLabelValues("error", "none")); got != 1 {
 		t.Errorf("expected 1, got %f", got)
 	}
 }
 
 func TestRecordTxContent_NoScripts(t *testing.T) {
 	setup()
-	RecordTxContent("none", false, false)
+	RecordTxContent("none", "none", false, false)
 
-	if got := testutil.ToFloat64(txSubmitScriptTypeTotal.WithLabelValues("none")); got != 1 {
+	if got := testutil.ToFloat64(txSubmitScriptTypeTotal.WithLabelValues("none", "none")); got != 1 {
 		t.Errorf("script_type: expected 1, got %f", got)
 	}
-	if got := testutil.ToFloat64(txSubmitHasMintingTotal.WithLabelValues("false")); got != 1 {
+	if got := testutil.ToFloat64(txSubmitHasMintingTotal.WithLabelValues("false", "none")); got != 1 {
 		t.Errorf("has_minting: expected 1, got %f", got)
 	}
-	if got := testutil.ToFloat64(txSubmitHasReferenceInputsTotal.WithLabelValues("false")); got != 1 {
+	if got := testutil.ToFloat64(txSubmitHasReferenceInputsTotal.WithLabelValues("false", "none")); got != 1 {
 		t.Errorf("has_reference_inputs: expected 1, got %f", got)
 	}
 }
 
 func TestRecordTxContent_PlutusV3WithMintingAndRefInputs(t *testing.T) {
 	setup()
-	RecordTxContent("plutus_v3", true, true)
+	RecordTxContent("minswap", "plutus_v3", true, true)
 
-	if got := testutil.ToFloat64(txSubmitScriptTypeTotal.WithLabelValues("plutus_v3")); got != 1 {
+	if got := testutil.ToFloat64(txSubmitScriptTypeTotal.WithLabelValues("plutus_v3", "minswap")); got != 1 {
 		t.Errorf("script_type: expected 1, got %f", got)
 	}
-	if got := testutil.ToFloat64(txSubmitHasMintingTotal.WithLabelValues("true")); got != 1 {
+	if got := testutil.ToFloat64(txSubmitHasMintingTotal.WithLabelValues("true", "minswap")); got != 1 {
 		t.Errorf("has_minting: expected 1, got %f", got)
 	}
-	if got := testutil.ToFloat64(txSubmitHasReferenceInputsTotal.WithLabelValues("true")); got != 1 {
+	if got := testutil.ToFloat64(txSubmitHasReferenceInputsTotal.WithLabelValues("true", "minswap")); got != 1 {
 		t.Errorf("has_reference_inputs: expected 1, got %f", got)
 	}
 }
@@ -94,3 +97,216 @@ func TestIncTxSubmitFailCount(t *testing.T) {
 		t.Errorf("expected 1, got %f", got)
 	}
 }
+
+func TestRecordMempool(t *testing.T) {
+	setup()
+	RecordMempool(1000, 400, 8, 2)
+
+	if got := testutil.ToFloat64(mempoolCapacityBytes); got != 1000 {
+		t.Errorf("capacity_bytes: expected 1000, got %f", got)
+	}
+	if got := testutil.ToFloat64(mempoolSizeBytes); got != 400 {
+		t.Errorf("size_bytes: expected 400, got %f", got)
+	}
+	if got := testutil.ToFloat64(mempoolTxCount); got != 8 {
+		t.Errorf("tx_count: expected 8, got %f", got)
+	}
+	if got := testutil.ToFloat64(mempoolOwnPendingTxs); got != 2 {
+		t.Errorf("own_pending_txs: expected 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(mempoolOwnPendingRatio); got != 0.25 {
+		t.Errorf("own_pending_ratio: expected 0.25, got %f", got)
+	}
+}
+
+func TestRecordMempool_EmptyMempool(t *testing.T) {
+	setup()
+	RecordMempool(1000, 0, 0, 0)
+
+	if got := testutil.ToFloat64(mempoolOwnPendingRatio); got != 0 {
+		t.Errorf("own_pending_ratio: expected 0, got %f", got)
+	}
+}
+
+func TestRecordInputCheck_MissingInputs(t *testing.T) {
+	setup()
+	RecordInputCheck("missing_inputs", []string{"input", "input", "collateral"})
+
+	if got := testutil.ToFloat64(txSubmitInputCheckTotal.WithLabelValues("missing_inputs")); got != 1 {
+		t.Errorf("result: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitMissingInputsTotal.WithLabelValues("input")); got != 2 {
+		t.Errorf("input: expected 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitMissingInputsTotal.WithLabelValues("collateral")); got != 1 {
+		t.Errorf("collateral: expected 1, got %f", got)
+	}
+}
+
+func TestRecordInputCheck_OK(t *testing.T) {
+	setup()
+	RecordInputCheck("ok", nil)
+
+	if got := testutil.ToFloat64(txSubmitInputCheckTotal.WithLabelValues("ok")); got != 1 {
+		t.Errorf("result: expected 1, got %f", got)
+	}
+	if got := testutil.CollectAndCount(txSubmitMissingInputsTotal); got != 0 {
+		t.Errorf("expected no missing input series, got %d", got)
+	}
+}
+
+func TestRecordPolicyDenied(t *testing.T) {
+	setup()
+	RecordPolicyDenied("addresses.deny")
+	RecordPolicyDenied("addresses.deny")
+	RecordPolicyDenied("policyIds.allow")
+
+	if got := testutil.ToFloat64(txSubmitPolicyDeniedTotal.WithLabelValues("addresses.deny")); got != 2 {
+		t.Errorf("addresses.deny: expected 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitPolicyDeniedTotal.WithLabelValues("policyIds.allow")); got != 1 {
+		t.Errorf("policyIds.allow: expected 1, got %f", got)
+	}
+}
+
+func TestRecordAdmissionExpression(t *testing.T) {
+	setup()
+	RecordAdmissionExpression("maxFee", "pass")
+	RecordAdmissionExpression("maxFee", "fail")
+	RecordAdmissionExpression("maxFee", "pass")
+
+	if got := testutil.ToFloat64(txSubmitAdmissionExprTotal.WithLabelValues("maxFee", "pass")); got != 2 {
+		t.Errorf("pass: expected 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitAdmissionExprTotal.WithLabelValues("maxFee", "fail")); got != 1 {
+		t.Errorf("fail: expected 1, got %f", got)
+	}
+}
+
+func TestRecordNodeEra(t *testing.T) {
+	setup()
+	RecordNodeEra("Babbage")
+	RecordNodeEra("Conway")
+
+	if got := testutil.CollectAndCount(nodeEra); got != 1 {
+		t.Errorf("expected only the current era series, got %d", got)
+	}
+	if got := testutil.ToFloat64(nodeEra.WithLabelValues("Conway")); got != 1 {
+		t.Errorf("Conway: expected 1, got %f", got)
+	}
+}
+
+func TestRecordTxGovernance(t *testing.T) {
+	setup()
+	RecordTxGovernance(
+		"none",
+		map[string]int{"drep": 2, "stake_pool": 1},
+		map[string]int{"info": 1},
+		map[string]int{"vote_delegation": 1},
+		5_000_000,
+	)
+	RecordTxGovernance("none", map[string]int{"drep": 1}, nil, nil, 0)
+	RecordTxGovernance("minswap", map[string]int{"drep": 1}, nil, nil, 0)
+
+	if got := testutil.ToFloat64(txSubmitVotesTotal.WithLabelValues("drep", "none")); got != 3 {
+		t.Errorf("drep votes: expected 3, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitVotesTotal.WithLabelValues("drep", "minswap")); got != 1 {
+		t.Errorf("minswap drep votes: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitVotesTotal.WithLabelValues("stake_pool", "none")); got != 1 {
+		t.Errorf("stake_pool votes: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitProposalsTotal.WithLabelValues("info", "none")); got != 1 {
+		t.Errorf("info proposals: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitGovCertificatesTotal.WithLabelValues("vote_delegation", "none")); got != 1 {
+		t.Errorf("vote_delegation certificates: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitTreasuryDonationsTotal.WithLabelValues("none")); got != 1 {
+		t.Errorf("donations: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitTreasuryDonationLovelace.WithLabelValues("none")); got != 5_000_000 {
+		t.Errorf("donation lovelace: expected 5000000, got %f", got)
+	}
+}
+
+func TestRecordTxStaking(t *testing.T) {
+	setup()
+	RecordTxStaking("none", map[string]int{"stake_registration": 1, "delegation": 1}, 2, 4_000_000)
+	RecordTxStaking("none", map[string]int{"delegation": 1}, 0, 0)
+
+	if got := testutil.ToFloat64(txSubmitCertificatesTotal.WithLabelValues("stake_registration", "none")); got != 1 {
+		t.Errorf("stake_registration: expected 1, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitCertificatesTotal.WithLabelValues("delegation", "none")); got != 2 {
+		t.Errorf("delegation: expected 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitWithdrawalsTotal.WithLabelValues("none")); got != 2 {
+		t.Errorf("withdrawals: expected 2, got %f", got)
+	}
+	if got := testutil.ToFloat64(txSubmitWithdrawalLovelace.WithLabelValues("none")); got != 4_000_000 {
+		t.Errorf("withdrawal lovelace: expected 4000000, got %f", got)
+	}
+}
+
+func TestConfigureHistograms(t *testing.T) {
+	setup()
+	if err := ConfigureHistograms(nil, []float64{1000, 2000}, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	RecordTxSize("none", 1500, 200_000)
+	want := `
+# HELP tx_submit_tx_size_bytes Size of submitted transactions in bytes.
+# TYPE tx_submit_tx_size_bytes histogram
+tx_submit_tx_size_bytes_bucket{dapp="none",le="1000"} 0
+tx_submit_tx_size_bytes_bucket{dapp="none",le="2000"} 1
+tx_submit_tx_size_bytes_bucket{dapp="none",le="+Inf"} 1
+tx_submit_tx_size_bytes_sum{dapp="none"} 1500
+tx_submit_tx_size_bytes_count{dapp="none"} 1
+`
+	if err := testutil.CollectAndCompare(txSubmitTxSizeBytes, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(txSubmitTxFeeLovelace); got != 1 {
+		t.Errorf("tx fee: expected 1 series, got %d", got)
+	}
+}
+
+func TestConfigureHistograms_NotIncreasing(t *testing.T) {
+	setup()
+	if err := ConfigureHistograms([]float64{1, 0.5}, nil, nil); err == nil {
+		t.Error("expected error for buckets out of order")
+	}
+	if err := ConfigureHistograms(nil, nil, []float64{200_000, 200_000}); err == nil {
+		t.Error("expected error for duplicate buckets")
+	}
+}
+
+func TestObserveNodeCalls(t *testing.T) {
+	setup()
+	node := "unix:///ipc/node.socket"
+	ObserveSubmitRequest("accepted", node, "none", 50*time.Millisecond)
+	ObserveTxParse("ok", node, time.Millisecond)
+	ObserveNodeDial("ok", node, 10*time.Millisecond)
+	ObserveNodeDial("error", node, 10*time.Millisecond)
+	ObserveLocalTxSubmission("rejected", node, 20*time.Millisecond)
+	ObserveHasTx("not_found", node, 5*time.Millisecond)
+
+	tests := []struct {
+		name      string
+		histogram *prometheus.HistogramVec
+		want      int
+	}{
+		{"request", txSubmitRequestDuration, 1},
+		{"parse", txSubmitParseDuration, 1},
+		{"dial", nodeDialDuration, 2},
+		{"local tx submission", nodeLocalTxSubmitDuration, 1},
+		{"has tx", nodeHasTxDuration, 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.CollectAndCount(tt.histogram); got != tt.want {
+			t.Errorf("%s: expected %d series, got %d", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/conway"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+// admissionExpressionCostLimit bounds the work a single admission expression
+// may do, so a badly written expression can't stall submissions.
+const admissionExpressionCostLimit = 1_000_000
+
+// AdmissionExpression is a Common Expression Language (CEL) expression over
+// the transaction, available as the variable tx of type AdmissionTx. The
+// expression must evaluate to true for the transaction to be admitted.
+type AdmissionExpression struct {
+	Name       string `yaml:"name"`
+	Expression string `yaml:"expression"`
+	// Message is returned when the expression refuses a transaction, and
+	// defaults to the expression itself.
+	Message string `yaml:"message"`
+}
+
+// AdmissionTx is the view of a transaction given to admission expressions.
+// Field names in expressions are the JSON names. Amounts are in lovelace,
+// and asset maps are keyed by "<policy ID>.<hex asset name>".
+type AdmissionTx struct {
+	Hash            string                 `json:"hash"`
+	Era             string                 `json:"era"`
+	Size            int64                  `json:"size"`
+	Fee             int64                  `json:"fee"`
+	Ttl             int64                  `json:"ttl"`
+	ValidityStart   int64                  `json:"validityStart"`
+	Inputs          []string               `json:"inputs"`
+	ReferenceInputs []string               `json:"referenceInputs"`
+	Collateral      []string               `json:"collateral"`
+	Outputs         []AdmissionOutput      `json:"outputs"`
+	Mint            map[string]int64       `json:"mint"`
+	Certificates    []AdmissionCertificate `json:"certificates"`
+	Withdrawals     map[string]int64       `json:"withdrawals"`
+	Votes           []AdmissionVote        `json:"votes"`
+	Proposals       []AdmissionProposal    `json:"proposals"`
+	Donation        int64                  `json:"donation"`
+	MetadataLabels  []int64                `json:"metadataLabels"`
+	// ScriptTypes lists the kinds of scripts in the witness set: "native",
+	// "plutus_v1", "plutus_v2" and "plutus_v3".
+	ScriptTypes     []string `json:"scriptTypes"`
+	RequiredSigners []string `json:"requiredSigners"`
+	VkeyWitnesses   int64    `json:"vkeyWitnesses"`
+}
+
+type AdmissionOutput struct {
+	Address      string           `json:"address"`
+	Lovelace     int64            `json:"lovelace"`
+	Assets       map[string]int64 `json:"assets"`
+	HasDatum     bool             `json:"hasDatum"`
+	HasScriptRef bool             `json:"hasScriptRef"`
+}
+
+type AdmissionCertificate struct {
+	// Type is the certificate kind in snake case, such as
+	// "stake_registration" or "vote_delegation".
+	Type string `json:"type"`
+	// StakeCredential is the hex hash of the stake credential the
+	// certificate applies to, if any.
+	StakeCredential string `json:"stakeCredential"`
+}
+
+type AdmissionVote struct {
+	// VoterType is "constitutional_committee", "drep" or "stake_pool".
+	VoterType   string `json:"voterType"`
+	Voter       string `json:"voter"`
+	GovActionId string `json:"govActionId"`
+	// Vote is "yes", "no" or "abstain".
+	Vote string `json:"vote"`
+}
+
+type AdmissionProposal struct {
+	// ActionType is the governance action kind in snake case, such as
+	// "treasury_withdrawal" or "info".
+	ActionType    string `json:"actionType"`
+	Deposit       int64  `json:"deposit"`
+	RewardAccount string `json:"rewardAccount"`
+}
+
+// certificateTypeNames maps certificate types to the names used in
+// AdmissionCertificate.
+var certificateTypeNames = map[uint]string{
+	uint(lcommon.CertificateTypeStakeRegistration):               "stake_registration",
+	uint(lcommon.CertificateTypeStakeDeregistration):             "stake_deregistration",
+	uint(lcommon.CertificateTypeStakeDelegation):                 "stake_delegation",
+	uint(lcommon.CertificateTypePoolRegistration):                "pool_registration",
+	uint(lcommon.CertificateTypePoolRetirement):                  "pool_retirement",
+	uint(lcommon.CertificateTypeGenesisKeyDelegation):            "genesis_key_delegation",
+	uint(lcommon.CertificateTypeMoveInstantaneousRewards):        "move_instantaneous_rewards",
+	uint(lcommon.CertificateTypeRegistration):                    "registration",
+	uint(lcommon.CertificateTypeDeregistration):                  "deregistration",
+	uint(lcommon.CertificateTypeVoteDelegation):                  "vote_delegation",
+	uint(lcommon.CertificateTypeStakeVoteDelegation):             "stake_vote_delegation",
+	uint(lcommon.CertificateTypeStakeRegistrationDelegation):     "stake_registration_delegation",
+	uint(lcommon.CertificateTypeVoteRegistrationDelegation):      "vote_registration_delegation",
+	uint(lcommon.CertificateTypeStakeVoteRegistrationDelegation): "stake_vote_registration_delegation",
+	uint(lcommon.CertificateTypeAuthCommitteeHot):                "auth_committee_hot",
+	uint(lcommon.CertificateTypeResignCommitteeCold):             "resign_committee_cold",
+	uint(lcommon.CertificateTypeRegistrationDrep):                "drep_registration",
+	uint(lcommon.CertificateTypeDeregistrationDrep):              "drep_deregistration",
+	uint(lcommon.CertificateTypeUpdateDrep):                      "drep_update",
+}
+
+func certificateTypeName(cert lcommon.Certificate) string {
+	if name, ok := certificateTypeNames[cert.Type()]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func voterTypeName(voter *lcommon.Voter) string {
+	switch voter.Type {
+	case lcommon.VoterTypeConstitutionalCommitteeHotKeyHash, lcommon.VoterTypeConstitutionalCommitteeHotScriptHash:
+		return "constitutional_committee"
+	case lcommon.VoterTypeDRepKeyHash, lcommon.VoterTypeDRepScriptHash:
+		return "drep"
+	case lcommon.VoterTypeStakingPoolKeyHash:
+		return "stake_pool"
+	default:
+		return "unknown"
+	}
+}
+
+func voteName(vote uint8) string {
+	switch vote {
+	case lcommon.GovVoteYes:
+		return "yes"
+	case lcommon.GovVoteNo:
+		return "no"
+	case lcommon.GovVoteAbstain:
+		return "abstain"
+	default:
+		return "unknown"
+	}
+}
+
+func govActionTypeName(action lcommon.GovAction) string {
+	switch action.(type) {
+	case *conway.ConwayParameterChangeGovAction:
+		return "parameter_change"
+	case *lcommon.HardForkInitiationGovAction:
+		return "hard_fork_initiation"
+	case *lcommon.TreasuryWithdrawalGovAction:
+		return "treasury_withdrawal"
+	case *lcommon.NoConfidenceGovAction:
+		return "no_confidence"
+	case *lcommon.UpdateCommitteeGovAction:
+		return "update_committee"
+	case *lcommon.NewConstitutionGovAction:
+		return "new_constitution"
+	case *lcommon.InfoGovAction:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// clampInt64 converts an amount to int64, saturating at the int64 range.
+func clampInt64(v *big.Int) int64 {
+	switch {
+	case v == nil:
+		return 0
+	case v.IsInt64():
+		return v.Int64()
+	case v.Sign() > 0:
+		return math.MaxInt64
+	default:
+		return math.MinInt64
+	}
+}
+
+func uintToInt64(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
+
+func assetMap(m *lcommon.MultiAsset[*big.Int]) map[string]int64 {
+	ret := map[string]int64{}
+	if m == nil {
+		return ret
+	}
+	for _, policyId := range m.Policies() {
+		for _, name := range m.Assets(policyId) {
+			ret[policyId.String()+"."+hex.EncodeToString(name)] = clampInt64(m.Asset(policyId, name))
+		}
+	}
+	return ret
+}
+
+func inputStrings(inputs []ledger.TransactionInput) []string {
+	ret := make([]string, len(inputs))
+	for i, input := range inputs {
+		ret[i] = input.String()
+	}
+	return ret
+}
+
+// NewAdmissionTx builds the view of a transaction given to admission
+// expressions. size is the size of the transaction CBOR in bytes.
+func NewAdmissionTx(tx ledger.Transaction, size int) *AdmissionTx {
+	ret := &AdmissionTx{
+		Hash:            tx.Hash().String(),
+		Era:             strings.ToLower(ledger.GetEraById(uint8(tx.Type())).Name), // #nosec G115
+		Size:            int64(size),
+		Fee:             clampInt64(tx.Fee()),
+		Ttl:             uintToInt64(tx.TTL()),
+		ValidityStart:   uintToInt64(tx.ValidityIntervalStart()),
+		Inputs:          inputStrings(tx.Inputs()),
+		ReferenceInputs: inputStrings(tx.ReferenceInputs()),
+		Collateral:      inputStrings(tx.Collateral()),
+		Outputs:         []AdmissionOutput{},
+		Mint:            assetMap(tx.AssetMint()),
+		Certificates:    []AdmissionCertificate{},
+		Withdrawals:     map[string]int64{},
+		Votes:           []AdmissionVote{},
+		Proposals:       []AdmissionProposal{},
+		Donation:        clampInt64(tx.Donation()),
+		MetadataLabels:  []int64{},
+		ScriptTypes:     []string{},
+		RequiredSigners: []string{},
+	}
+	for _, output := range tx.Outputs() {
+		ret.Outputs = append(ret.Outputs, AdmissionOutput{
+			Address:      output.Address().String(),
+			Lovelace:     clampInt64(output.Amount()),
+			Assets:       assetMap(output.Assets()),
+			HasDatum:     output.Datum() != nil || output.DatumHash() != nil,
+			HasScriptRef: output.ScriptRef() != nil,
+		})
+	}
+	for _, cert := range tx.Certificates() {
+		c := AdmissionCertificate{Type: certificateTypeName(cert)}
+		if cred := certStakeCredential(cert); cred != nil {
+			c.StakeCredential = cred.Credential.String()
+		}
+		ret.Certificates = append(ret.Certificates, c)
+	}
+	for addr, amount := range tx.Withdrawals() {
+		if addr != nil {
+			ret.Withdrawals[addr.String()] = clampInt64(amount)
+		}
+	}
+	for voter, votes := range tx.VotingProcedures() {
+		if voter == nil {
+			continue
+		}
+		for actionId, procedure := range votes {
+			vote := AdmissionVote{
+				VoterType: voterTypeName(voter),
+				Voter:     hex.EncodeToString(voter.Hash[:]),
+				Vote:      voteName(procedure.Vote),
+			}
+			if actionId != nil {
+				vote.GovActionId = fmt.Sprintf("%x#%d", actionId.TransactionId, actionId.GovActionIdx)
+			}
+			ret.Votes = append(ret.Votes, vote)
+		}
+	}
+	for _, proposal := range tx.ProposalProcedures() {
+		ret.Proposals = append(ret.Proposals, AdmissionProposal{
+			ActionType:    govActionTypeName(proposal.GovAction()),
+			Deposit:       uintToInt64(proposal.Deposit()),
+			RewardAccount: proposal.RewardAccount().String(),
+		})
+	}
+	if m, ok := tx.Metadata().(lcommon.MetaMap); ok {
+		for _, pair := range m.Pairs {
+			if label, ok := pair.Key.(lcommon.MetaInt); ok {
+				ret.MetadataLabels = append(ret.MetadataLabels, clampInt64(label.Value))
+			}
+		}
+	}
+	for _, signer := range tx.RequiredSigners() {
+		ret.RequiredSigners = append(ret.RequiredSigners, signer.String())
+	}
+	if w := tx.Witnesses(); w != nil {
+		ret.VkeyWitnesses = int64(len(w.Vkey()))
+		kinds := []struct {
+			name    string
+			present bool
+		}{
+			{"native", len(w.NativeScripts()) > 0},
+			{"plutus_v1", len(w.PlutusV1Scripts()) > 0},
+			{"plutus_v2", len(w.PlutusV2Scripts()) > 0},
+			{"plutus_v3", len(w.PlutusV3Scripts()) > 0},
+		}
+		for _, k := range kinds {
+			if k.present {
+				ret.ScriptTypes = append(ret.ScriptTypes, k.name)
+			}
+		}
+	}
+	return ret
+}
+
+// compiledExpression is an admission expression ready to be evaluated.
+type compiledExpression struct {
+	name    string
+	message string
+	program cel.Program
+}
+
+func newAdmissionEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		ext.NativeTypes(reflect.TypeFor[AdmissionTx](), ext.ParseStructTag("json")),
+		cel.Variable("tx", cel.ObjectType("submit.AdmissionTx")),
+		ext.Strings(),
+	)
+}
+
+// compileAdmissionExpressions type checks admission expressions and prepares
+// them for evaluation. Expressions must have a unique name and evaluate to a
+// bool.
+func compileAdmissionExpressions(exprs []AdmissionExpression) ([]compiledExpression, error) {
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+	env, err := newAdmissionEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failure creating expression environment: %w", err)
+	}
+	names := make(map[string]struct{}, len(exprs))
+	ret := make([]compiledExpression, 0, len(exprs))
+	for _, expr := range exprs {
+		if expr.Name == "" {
+			return nil, fmt.Errorf("admission expression %q has no name", expr.Expression)
+		}
+		if _, ok := names[expr.Name]; ok {
+			return nil, fmt.Errorf("duplicate admission expression name: %s", expr.Name)
+		}
+		names[expr.Name] = struct{}{}
+		ast, issues := env.Compile(expr.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid admission expression %s: %w", expr.Name, issues.Err())
+		}
+		if !ast.OutputType().IsExactType(cel.BoolType) {
+			return nil, fmt.Errorf(
+				"admission expression %s must evaluate to bool, not %s",
+				expr.Name,
+				ast.OutputType(),
+			)
+		}
+		program, err := env.Program(ast, cel.CostLimit(admissionExpressionCostLimit))
+		if err != nil {
+			return nil, fmt.Errorf("invalid admission expression %s: %w", expr.Name, err)
+		}
+		message := expr.Message
+		if message == "" {
+			message = expr.Expression
+		}
+		ret = append(ret, compiledExpression{
+			name:    expr.Name,
+			message: message,
+			program: program,
+		})
+	}
+	return ret, nil
+}
+
+// eval evaluates the expression against a transaction, returning whether it
+// admits the transaction.
+func (e *compiledExpression) eval(tx *AdmissionTx) (bool, error) {
+	out, _, err := e.program.Eval(map[string]any{"tx": tx})
+	if err != nil {
+		return false, err
+	}
+	admit, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.New("expression did not evaluate to bool")
+	}
+	return admit, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewAdmissionTx(t *testing.T) {
+	t.Parallel()
+	payee := testAddress(t, 0x01, 0x02)
+	txBytes := buildPolicyTx(t, payee)
+	tx, err := decodeTx(txBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	admissionTx := NewAdmissionTx(tx, len(txBytes))
+
+	if admissionTx.Era != "conway" {
+		t.Errorf("Era: want conway, got %s", admissionTx.Era)
+	}
+	if admissionTx.Fee != 100_000 || admissionTx.Size != int64(len(txBytes)) {
+		t.Errorf("unexpected fee %d / size %d", admissionTx.Fee, admissionTx.Size)
+	}
+	if len(admissionTx.Outputs) != 1 || admissionTx.Outputs[0].Address != payee.String() ||
+		admissionTx.Outputs[0].Lovelace != 2_000_000 {
+		t.Errorf("unexpected outputs: %+v", admissionTx.Outputs)
+	}
+	mintKey := strings.Repeat("cc", 28) + ".746f6b656e"
+	if admissionTx.Mint[mintKey] != 1 {
+		t.Errorf("expected mint of %s, got %v", mintKey, admissionTx.Mint)
+	}
+	if !slices.Equal(admissionTx.MetadataLabels, []int64{674}) {
+		t.Errorf("unexpected metadata labels: %v", admissionTx.MetadataLabels)
+	}
+	if !slices.Equal(admissionTx.ScriptTypes, []string{"native"}) {
+		t.Errorf("unexpected script types: %v", admissionTx.ScriptTypes)
+	}
+}
+
+func TestPolicy_EvaluateExpressions(t *testing.T) {
+	t.Parallel()
+	txBytes := buildPolicyTx(t, testAddress(t, 0x01, 0x02))
+	tests := []struct {
+		name       string
+		expression string
+		wantAdmit  bool
+	}{
+		{"fee under 5 ADA", "tx.fee <= 5000000", true},
+		{"fee under 0.01 ADA", "tx.fee <= 10000", false},
+		{"label 674 for large batches", "size(tx.outputs) <= 50 || 674 in tx.metadataLabels", true},
+		{"label 721 required", "721 in tx.metadataLabels", false},
+		{"no plutus scripts", "!tx.scriptTypes.exists(s, s.startsWith('plutus'))", true},
+		{"output value", "tx.outputs.all(o, o.lovelace >= 1000000)", true},
+		{"mint under known policy", "tx.mint.all(k, k.startsWith('" + strings.Repeat("dd", 28) + "'))", false},
+		{"no certificates or votes", "size(tx.certificates) == 0 && size(tx.votes) == 0", true},
+		{"runtime error", "tx.withdrawals['stake1missing'] > 0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := NewPolicy(PolicyRules{
+				Expressions: []AdmissionExpression{{Name: "test", Expression: tt.expression}},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var results []string
+			policy.ObserveExpressions(func(name, result string) {
+				results = append(results, name+"="+result)
+			})
+			violation, err := policy.Evaluate(txBytes, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantAdmit {
+				if violation != nil {
+					t.Fatalf("expected transaction to be admitted, got %s", violation.Error())
+				}
+				if !slices.Equal(results, []string{"test=pass"}) {
+					t.Errorf("unexpected observed results: %v", results)
+				}
+				return
+			}
+			if violation == nil || violation.Rule != "expressions.test" {
+				t.Fatalf("expected violation of expressions.test, got %+v", violation)
+			}
+			if len(results) != 1 || results[0] == "test=pass" {
+				t.Errorf("unexpected observed results: %v", results)
+			}
+		})
+	}
+}
+
+func TestPolicy_ExpressionMessage(t *testing.T) {
+	t.Parallel()
+	txBytes := buildPolicyTx(t, testAddress(t, 0x01, 0x02))
+	policy, err := NewPolicy(
+		PolicyRules{Expressions: []AdmissionExpression{
+			{Name: "maxFee", Expression: "tx.fee <= 5000000"},
+		}},
+		PolicyRules{Expressions: []AdmissionExpression{
+			{Name: "minFee", Expression: "tx.fee >= 200000", Message: "fee must be at least 0.2 ADA"},
+		}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	violation, err := policy.Evaluate(txBytes, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if violation == nil || violation.Rule != "expressions.minFee" || violation.Value != "fee must be at least 0.2 ADA" {
+		t.Fatalf("unexpected violation: %+v", violation)
+	}
+}
+
+func TestCompileAdmissionExpressions_Invalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		exprs []AdmissionExpression
+	}{
+		{"syntax error", []AdmissionExpression{{Name: "a", Expression: "tx.fee <="}}},
+		{"unknown field", []AdmissionExpression{{Name: "a", Expression: "tx.feee > 0"}}},
+		{"not bool", []AdmissionExpression{{Name: "a", Expression: "tx.fee"}}},
+		{"type mismatch", []AdmissionExpression{{Name: "a", Expression: "tx.era > 1"}}},
+		{"missing name", []AdmissionExpression{{Expression: "true"}}},
+		{"duplicate name", []AdmissionExpression{
+			{Name: "a", Expression: "true"},
+			{Name: "a", Expression: "false"},
+		}},
+	}
+	for _, tt := range tests {
+		if _, err := compileAdmissionExpressions(tt.exprs); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
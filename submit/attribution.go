@@ -0,0 +1,164 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AttributionNone is the attribution of a transaction that matches no dApp.
+const AttributionNone = "none"
+
+// MaxAttributionLabels bounds the number of dApp labels, as each one adds a
+// series to every metric labelled by dApp.
+const MaxAttributionLabels = 32
+
+var attributionLabelRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// AttributionRule lists the values that attribute a transaction to a dApp. A
+// transaction matches the rule if any of its values is listed.
+type AttributionRule struct {
+	// ScriptHashes are the hex hashes of scripts in the witness set, or
+	// referred to by the script credentials of the transaction, as in
+	// TxInfo.ScriptCredentials, so that transactions using reference
+	// scripts match too.
+	ScriptHashes []string `yaml:"scriptHashes"`
+	// PolicyIds are the hex policy IDs of minted or burned assets.
+	PolicyIds []string `yaml:"policyIds"`
+	// MetadataLabels are the top-level transaction metadata labels.
+	MetadataLabels []string `yaml:"metadataLabels"`
+	// AddressPrefixes are prefixes of the bech32 addresses of the outputs,
+	// such as a full script address or its payment part.
+	AddressPrefixes []string `yaml:"addressPrefixes"`
+}
+
+type attributionRule struct {
+	label           string
+	scriptHashes    policySet
+	policyIds       policySet
+	metadataLabels  map[uint64]struct{}
+	addressPrefixes []string
+}
+
+// Attribution attributes transactions to dApps by matching their content
+// against a set of rules. It is safe for concurrent use.
+type Attribution struct {
+	rules []attributionRule
+}
+
+// NewAttribution compiles rules keyed by dApp label. Labels must be lower
+// case alphanumeric with dashes or underscores, there may be at most
+// MaxAttributionLabels of them, and AttributionNone is reserved.
+func NewAttribution(rules map[string]AttributionRule) (*Attribution, error) {
+	if len(rules) > MaxAttributionLabels {
+		return nil, fmt.Errorf("too many dApp labels: %d, the maximum is %d", len(rules), MaxAttributionLabels)
+	}
+	a := &Attribution{}
+	// Rules are checked in label order, so the first matching label is
+	// always the same
+	labels := make([]string, 0, len(rules))
+	for label := range rules {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+	for _, label := range labels {
+		if label == AttributionNone || !attributionLabelRe.MatchString(label) {
+			return nil, fmt.Errorf("invalid dApp label %q", label)
+		}
+		rule := rules[label]
+		compiled := attributionRule{
+			label:          label,
+			scriptHashes:   make(policySet),
+			policyIds:      make(policySet),
+			metadataLabels: make(map[uint64]struct{}),
+		}
+		for _, value := range rule.ScriptHashes {
+			hash, err := normalizeHash(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("dApp %s script hash %w", label, err)
+			}
+			compiled.scriptHashes[hash] = struct{}{}
+		}
+		for _, value := range rule.PolicyIds {
+			hash, err := normalizeHash(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("dApp %s policy ID %w", label, err)
+			}
+			compiled.policyIds[hash] = struct{}{}
+		}
+		for _, value := range rule.MetadataLabels {
+			metadataLabel, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("dApp %s metadata label %q: must be an unsigned integer", label, value)
+			}
+			compiled.metadataLabels[metadataLabel] = struct{}{}
+		}
+		for _, value := range rule.AddressPrefixes {
+			prefix := strings.ToLower(strings.TrimSpace(value))
+			if prefix == "" {
+				return nil, fmt.Errorf("dApp %s has an empty address prefix", label)
+			}
+			compiled.addressPrefixes = append(compiled.addressPrefixes, prefix)
+		}
+		a.rules = append(a.rules, compiled)
+	}
+	return a, nil
+}
+
+// Attribute returns the label of the first dApp, in label order, whose rule
+// matches the transaction, or AttributionNone. info may be nil for a
+// transaction that could not be parsed, and a nil Attribution matches
+// nothing.
+func (a *Attribution) Attribute(info *TxInfo) string {
+	if a == nil || info == nil {
+		return AttributionNone
+	}
+	for i := range a.rules {
+		if a.rules[i].matches(info) {
+			return a.rules[i].label
+		}
+	}
+	return AttributionNone
+}
+
+func (r *attributionRule) matches(info *TxInfo) bool {
+	for _, hash := range slices.Concat(info.ScriptHashes, info.ScriptCredentials) {
+		if _, ok := r.scriptHashes[hash]; ok {
+			return true
+		}
+	}
+	for _, policyId := range info.MintPolicyIds {
+		if _, ok := r.policyIds[policyId]; ok {
+			return true
+		}
+	}
+	for _, label := range info.MetadataLabels {
+		if _, ok := r.metadataLabels[label]; ok {
+			return true
+		}
+	}
+	for _, addr := range info.Addresses {
+		for _, prefix := range r.addressPrefixes {
+			if strings.HasPrefix(addr, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
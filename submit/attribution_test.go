@@ -0,0 +1,126 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+)
+
+func TestNewAttribution_Invalid(t *testing.T) {
+	tooMany := make(map[string]AttributionRule)
+	for i := range MaxAttributionLabels + 1 {
+		tooMany[fmt.Sprintf("dapp-%d", i)] = AttributionRule{}
+	}
+	testCases := []struct {
+		name  string
+		rules map[string]AttributionRule
+		want  string
+	}{
+		{"reserved label", map[string]AttributionRule{"none": {}}, `invalid dApp label "none"`},
+		{"upper case label", map[string]AttributionRule{"MinSwap": {}}, `invalid dApp label "MinSwap"`},
+		{"bad script hash", map[string]AttributionRule{"minswap": {ScriptHashes: []string{"abcd"}}}, "dApp minswap script hash"},
+		{"bad metadata label", map[string]AttributionRule{"minswap": {MetadataLabels: []string{"msg"}}}, "dApp minswap metadata label"},
+		{"empty address prefix", map[string]AttributionRule{"minswap": {AddressPrefixes: []string{" "}}}, "empty address prefix"},
+		{"too many labels", tooMany, "too many dApp labels"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewAttribution(tc.rules)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestAttribution_Attribute(t *testing.T) {
+	policy := bytes.Repeat([]byte{0xaa}, 28)
+	nativeScript := []any{uint(0), bytes.Repeat([]byte{0x11}, 28)}
+	mintTx := func() []byte {
+		body := buildMinimalConwayBody()
+		body[9] = map[gocbor.ByteString]map[gocbor.ByteString]int64{
+			gocbor.NewByteString(policy): {gocbor.NewByteString([]byte("token")): 1},
+		}
+		return buildConwayTx(t, body, map[uint]any{1: []any{nativeScript}})
+	}
+	parse := func(txBytes []byte) *TxInfo {
+		info, err := ParseTxInfo(txBytes)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return info
+	}
+	scriptInfo := parse(buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{1: []any{nativeScript}}))
+	if len(scriptInfo.ScriptHashes) != 1 {
+		t.Fatalf("expected one script hash, got %v", scriptInfo.ScriptHashes)
+	}
+
+	// A transaction running a reference script, paying back to the script
+	// address, carries no script in its witness set
+	dexScript := bytes.Repeat([]byte{0xbb}, 28)
+	dexBody := buildMinimalConwayBody()
+	dexBody[1] = []map[uint]any{{0: append([]byte{0x70}, dexScript...), 1: uint64(1_000_000_000)}}
+	dexInfo := parse(buildConwayTx(t, dexBody, map[uint]any{}))
+	if len(dexInfo.ScriptHashes) != 0 || len(dexInfo.ScriptCredentials) != 1 {
+		t.Fatalf("expected one script credential and no script, got %+v", dexInfo)
+	}
+
+	attribution, err := NewAttribution(map[string]AttributionRule{
+		"dex":       {ScriptHashes: []string{strings.Repeat("bb", 28)}},
+		"jpg-store": {PolicyIds: []string{strings.ToUpper(strings.Repeat("aa", 28))}},
+		"minswap":   {ScriptHashes: []string{scriptInfo.ScriptHashes[0]}},
+		"wallet":    {AddressPrefixes: []string{"ADDR_TEST1VQ"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testCases := []struct {
+		name string
+		info *TxInfo
+		want string
+	}{
+		// Both jpg-store and minswap match, and the first label wins
+		{"policy ID", parse(mintTx()), "jpg-store"},
+		{"script hash", scriptInfo, "minswap"},
+		{"script credential", dexInfo, "dex"},
+		{"address prefix", parse(buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})), "wallet"},
+		{"metadata label", &TxInfo{MetadataLabels: []uint64{674}}, AttributionNone},
+		{"unparsed", nil, AttributionNone},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := attribution.Attribute(tc.info); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+
+	labels, err := NewAttribution(map[string]AttributionRule{"cip20": {MetadataLabels: []string{"674"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := labels.Attribute(&TxInfo{MetadataLabels: []uint64{1, 674}}); got != "cip20" {
+		t.Errorf("want %q, got %q", "cip20", got)
+	}
+	var none *Attribution
+	if got := none.Attribute(scriptInfo); got != AttributionNone {
+		t.Errorf("want %q from a nil attribution, got %q", AttributionNone, got)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/protocol"
+	"github.com/blinklabs-io/gouroboros/protocol/localstatequery"
+)
+
+// ChainTip is the tip of the node's current chain.
+type ChainTip struct {
+	Slot    uint64 `json:"slot"`
+	Hash    string `json:"hash"`
+	BlockNo uint64 `json:"blockNo"`
+}
+
+// NodeStatus holds the chain state reported by the node over LocalStateQuery,
+// along with the slot clock needed to relate it to wall-clock time.
+type NodeStatus struct {
+	// ProtocolVersion is the negotiated NtC protocol version, without the
+	// NtC version bit.
+	ProtocolVersion uint16
+	NetworkMagic    uint32
+	Era             ledger.Era
+	Epoch           uint64
+	Tip             ChainTip
+	Clock           *SlotClock
+}
+
+// TipTime returns the wall-clock time of the chain tip slot.
+func (s *NodeStatus) TipTime() (time.Time, error) {
+	return s.Clock.SlotToTime(s.Tip.Slot)
+}
+
+// dialStateQuery dials the node with LocalStateQuery timeouts derived from
+// cfg.Timeout. Callers are responsible for closing the returned connection.
+func dialStateQuery(cfg *Config) (*ouroboros.Connection, error) {
+	if cfg.Timeout > math.MaxInt64 {
+		return nil, errors.New("given timeout too large")
+	}
+	if err := cfg.populateNetworkMagic(); err != nil {
+		return nil, fmt.Errorf("failed to populate networkMagic: %w", err)
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second // #nosec G115
+	return DialNode(
+		cfg.NetworkMagic,
+		cfg.NodeAddress,
+		cfg.NodePort,
+		cfg.SocketPath,
+		ouroboros.WithLocalStateQueryConfig(
+			localstatequery.NewConfig(
+				localstatequery.WithAcquireTimeout(timeout),
+				localstatequery.WithQueryTimeout(timeout),
+			),
+		),
+	)
+}
+
+// QueryNodeStatus performs a full NtC handshake with the node, which fails on
+// a network magic mismatch, and queries its chain tip along with the system
+// start and era history used to convert slots to wall-clock time. The era
+// history is not queried when cfg.Clock is set.
+func QueryNodeStatus(cfg *Config) (*NodeStatus, error) {
+	oConn, err := dialStateQuery(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oConn.Close()
+
+	client := oConn.LocalStateQuery().Client
+	eraId, err := client.GetCurrentEra()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting current era: %w", err)
+	}
+	point, err := client.GetChainPoint()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting chain tip: %w", err)
+	}
+	blockNo, err := client.GetChainBlockNo()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting chain block number: %w", err)
+	}
+	clock := cfg.Clock
+	if clock == nil {
+		systemStart, err := client.GetSystemStart()
+		if err != nil {
+			return nil, fmt.Errorf("failure getting system start: %w", err)
+		}
+		eraHistory, err := client.GetEraHistory()
+		if err != nil {
+			return nil, fmt.Errorf("failure getting era history: %w", err)
+		}
+		clock, err = slotClockFromNode(systemStart, eraHistory)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if blockNo < 0 {
+		blockNo = 0
+	}
+	if eraId < 0 || eraId > math.MaxUint8 {
+		return nil, fmt.Errorf("invalid era %d reported by node", eraId)
+	}
+	// The epoch number query is Shelley-only, so a node still syncing through
+	// Byron gets its epoch from the era history instead.
+	var epoch uint64
+	if eraId == ledger.EraIdByron {
+		epoch, err = clock.SlotToEpoch(point.Slot)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		epochNo, err := client.GetEpochNo()
+		if err != nil {
+			return nil, fmt.Errorf("failure getting epoch number: %w", err)
+		}
+		if epochNo < 0 {
+			return nil, fmt.Errorf("invalid epoch %d reported by node", epochNo)
+		}
+		epoch = uint64(epochNo)
+	}
+	version, _ := oConn.ProtocolVersion()
+	return &NodeStatus{
+		ProtocolVersion: version &^ protocol.ProtocolVersionNtCOffset,
+		NetworkMagic:    cfg.NetworkMagic,
+		Era:             ledger.GetEraById(uint8(eraId)),
+		Epoch:           epoch,
+		Tip: ChainTip{
+			Slot:    point.Slot,
+			Hash:    hex.EncodeToString(point.Hash),
+			BlockNo: uint64(blockNo),
+		},
+		Clock: clock,
+	}, nil
+}
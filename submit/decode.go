@@ -0,0 +1,533 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// TextEnvelope is the JSON envelope cardano-cli writes transactions in.
+type TextEnvelope struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	CborHex     string `json:"cborHex"`
+}
+
+// ReadTxBytes returns the transaction CBOR in data, which may be raw CBOR,
+// hex-encoded CBOR or a cardano-cli TextEnvelope.
+func ReadTxBytes(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var envelope TextEnvelope
+		if err := json.Unmarshal(trimmed, &envelope); err != nil {
+			return nil, fmt.Errorf("invalid TextEnvelope: %w", err)
+		}
+		if envelope.CborHex == "" {
+			return nil, errors.New("invalid TextEnvelope: missing cborHex")
+		}
+		txBytes, err := hex.DecodeString(envelope.CborHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TextEnvelope cborHex: %w", err)
+		}
+		return txBytes, nil
+	}
+	if txBytes, err := hex.DecodeString(string(trimmed)); err == nil {
+		return txBytes, nil
+	}
+	return data, nil
+}
+
+// DecodedTx is a JSON rendering of a transaction. Hashes and byte strings are
+// hex-encoded, addresses are bech32-encoded and Plutus data is rendered both
+// as CBOR and in the cardano-cli detailed JSON schema.
+type DecodedTx struct {
+	Hash      string           `json:"hash"`
+	Era       string           `json:"era"`
+	Size      int              `json:"size"`
+	IsValid   bool             `json:"isValid"`
+	Body      DecodedTxBody    `json:"body"`
+	Witnesses DecodedWitnesses `json:"witnesses"`
+	// Metadata is keyed by label and rendered in the cardano-cli "no schema"
+	// JSON format, with byte strings as 0x-prefixed hex. Maps with keys
+	// other than integers and strings are rendered as lists of k/v pairs.
+	Metadata any `json:"metadata,omitempty"`
+}
+
+// DecodedTxBody is the body of a DecodedTx. Optional fields are left out when
+// they are not set.
+type DecodedTxBody struct {
+	Inputs               []string                                        `json:"inputs"`
+	Outputs              []DecodedOutput                                 `json:"outputs"`
+	Fee                  *big.Int                                        `json:"fee"`
+	InvalidBefore        uint64                                          `json:"invalidBefore,omitempty"`
+	InvalidHereafter     uint64                                          `json:"invalidHereafter,omitempty"`
+	Certificates         []DecodedCertificate                            `json:"certificates,omitempty"`
+	Withdrawals          []DecodedWithdrawal                             `json:"withdrawals,omitempty"`
+	AuxDataHash          *lcommon.Blake2b256                             `json:"auxDataHash,omitempty"`
+	Mint                 *lcommon.MultiAsset[lcommon.MultiAssetTypeMint] `json:"mint,omitempty"`
+	ScriptDataHash       *lcommon.Blake2b256                             `json:"scriptDataHash,omitempty"`
+	Collateral           []string                                        `json:"collateral,omitempty"`
+	RequiredSigners      []lcommon.Blake2b224                            `json:"requiredSigners,omitempty"`
+	NetworkId            *uint                                           `json:"networkId,omitempty"`
+	CollateralReturn     *DecodedOutput                                  `json:"collateralReturn,omitempty"`
+	TotalCollateral      *big.Int                                        `json:"totalCollateral,omitempty"`
+	ReferenceInputs      []string                                        `json:"referenceInputs,omitempty"`
+	Votes                []DecodedVote                                   `json:"votes,omitempty"`
+	Proposals            []DecodedProposal                               `json:"proposals,omitempty"`
+	CurrentTreasuryValue *big.Int                                        `json:"currentTreasuryValue,omitempty"`
+	Donation             *big.Int                                        `json:"donation,omitempty"`
+}
+
+// DecodedOutput is a transaction output.
+type DecodedOutput struct {
+	Address   string                                            `json:"address"`
+	Amount    *big.Int                                          `json:"amount"`
+	Assets    *lcommon.MultiAsset[lcommon.MultiAssetTypeOutput] `json:"assets,omitempty"`
+	DatumHash *lcommon.Blake2b256                               `json:"datumHash,omitempty"`
+	// Datum is the inline datum of the output
+	Datum     *PlutusData    `json:"datum,omitempty"`
+	ScriptRef *DecodedScript `json:"scriptRef,omitempty"`
+}
+
+// PlutusData is Plutus data as CBOR and in the cardano-cli detailed JSON
+// schema, such as {"constructor": 0, "fields": [{"int": 42}]}.
+type PlutusData struct {
+	Hash string          `json:"hash,omitempty"`
+	Cbor string          `json:"cbor"`
+	Json json.RawMessage `json:"json,omitempty"`
+}
+
+// DecodedScript is a witness or reference script.
+type DecodedScript struct {
+	Hash string `json:"hash"`
+	Type string `json:"type"`
+	Cbor string `json:"cbor,omitempty"`
+	// Script is the cardano-cli JSON of a native script
+	Script any `json:"script,omitempty"`
+}
+
+// DecodedCertificate is a certificate, named like in admission expressions.
+type DecodedCertificate struct {
+	Type string `json:"type"`
+	Cbor string `json:"cbor"`
+	// Certificate holds the fields of the gouroboros certificate type
+	Certificate json.RawMessage `json:"certificate,omitempty"`
+}
+
+// DecodedWithdrawal is a reward withdrawal.
+type DecodedWithdrawal struct {
+	Address string   `json:"address"`
+	Amount  *big.Int `json:"amount"`
+}
+
+// DecodedAnchor is the anchor of a vote or proposal.
+type DecodedAnchor struct {
+	Url      string `json:"url"`
+	DataHash string `json:"dataHash"`
+}
+
+// DecodedVote is a governance vote.
+type DecodedVote struct {
+	VoterType string `json:"voterType"`
+	// Voter is the CIP-129 bech32 ID of the voter
+	Voter       string         `json:"voter"`
+	GovActionId string         `json:"govActionId"`
+	Vote        string         `json:"vote"`
+	Anchor      *DecodedAnchor `json:"anchor,omitempty"`
+}
+
+// DecodedProposal is a governance proposal.
+type DecodedProposal struct {
+	Deposit       uint64        `json:"deposit"`
+	RewardAccount string        `json:"rewardAccount"`
+	Type          string        `json:"type"`
+	Anchor        DecodedAnchor `json:"anchor"`
+	// GovAction holds the fields of the gouroboros governance action type
+	GovAction json.RawMessage `json:"govAction,omitempty"`
+}
+
+// DecodedVkeyWitness is a vkey witness, with the hash of its key.
+type DecodedVkeyWitness struct {
+	KeyHash   lcommon.Blake2b224 `json:"keyHash"`
+	Vkey      string             `json:"vkey"`
+	Signature string             `json:"signature"`
+}
+
+// DecodedBootstrapWitness is a Byron bootstrap witness.
+type DecodedBootstrapWitness struct {
+	PublicKey  string `json:"publicKey"`
+	Signature  string `json:"signature"`
+	ChainCode  string `json:"chainCode"`
+	Attributes string `json:"attributes"`
+}
+
+// DecodedRedeemer is a redeemer, with its declared execution units.
+type DecodedRedeemer struct {
+	Tag     lcommon.RedeemerTag `json:"tag"`
+	Index   uint32              `json:"index"`
+	Data    PlutusData          `json:"data"`
+	ExUnits lcommon.ExUnits     `json:"exUnits"`
+}
+
+// DecodedWitnesses is the witness set of a DecodedTx.
+type DecodedWitnesses struct {
+	Vkey       []DecodedVkeyWitness      `json:"vkey,omitempty"`
+	Bootstrap  []DecodedBootstrapWitness `json:"bootstrap,omitempty"`
+	Scripts    []DecodedScript           `json:"scripts,omitempty"`
+	PlutusData []PlutusData              `json:"plutusData,omitempty"`
+	Redeemers  []DecodedRedeemer         `json:"redeemers,omitempty"`
+}
+
+// DecodeTx decodes raw transaction CBOR of any known era and renders it as
+// JSON, without submitting it.
+func DecodeTx(txRawBytes []byte) (*DecodedTx, error) {
+	txType, err := ledger.DetermineTransactionType(txRawBytes)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse transaction to determine type: %w", err)
+	}
+	tx, err := ledger.NewTransactionFromCbor(txType, txRawBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction CBOR: %w", err)
+	}
+	ret := &DecodedTx{
+		Hash: tx.Hash().String(),
+		// #nosec G115 -- transaction types are era IDs
+		Era:     ledger.GetEraById(uint8(txType)).Name,
+		Size:    len(txRawBytes),
+		IsValid: tx.IsValid(),
+		Body: DecodedTxBody{
+			Inputs:               inputStrings(tx.Inputs()),
+			Fee:                  tx.Fee(),
+			InvalidBefore:        tx.ValidityIntervalStart(),
+			InvalidHereafter:     tx.TTL(),
+			AuxDataHash:          tx.AuxDataHash(),
+			Mint:                 tx.AssetMint(),
+			ScriptDataHash:       tx.ScriptDataHash(),
+			Collateral:           inputStrings(tx.Collateral()),
+			RequiredSigners:      tx.RequiredSigners(),
+			TotalCollateral:      nonZero(tx.TotalCollateral()),
+			ReferenceInputs:      inputStrings(tx.ReferenceInputs()),
+			CurrentTreasuryValue: nonZero(tx.CurrentTreasuryValue()),
+			Donation:             nonZero(tx.Donation()),
+		},
+	}
+	if ret.Body.Fee == nil {
+		ret.Body.Fee = new(big.Int)
+	}
+	if networkId, ok := txBodyNetworkId(txRawBytes); ok {
+		ret.Body.NetworkId = &networkId
+	}
+	ret.Body.Outputs = make([]DecodedOutput, 0, len(tx.Outputs()))
+	for _, output := range tx.Outputs() {
+		ret.Body.Outputs = append(ret.Body.Outputs, decodeOutput(output))
+	}
+	if output := tx.CollateralReturn(); output != nil {
+		collateralReturn := decodeOutput(output)
+		ret.Body.CollateralReturn = &collateralReturn
+	}
+	for _, cert := range tx.Certificates() {
+		ret.Body.Certificates = append(ret.Body.Certificates, DecodedCertificate{
+			Type:        certificateTypeName(cert),
+			Cbor:        hex.EncodeToString(cert.Cbor()),
+			Certificate: ledgerJSON(cert),
+		})
+	}
+	for addr, amount := range tx.Withdrawals() {
+		if addr == nil {
+			continue
+		}
+		ret.Body.Withdrawals = append(ret.Body.Withdrawals, DecodedWithdrawal{
+			Address: addr.String(),
+			Amount:  amount,
+		})
+	}
+	// Withdrawals are a map, so sort them for a stable result
+	slices.SortFunc(ret.Body.Withdrawals, func(a, b DecodedWithdrawal) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+	ret.Body.Votes = decodeVotes(tx.VotingProcedures())
+	for _, proposal := range tx.ProposalProcedures() {
+		ret.Body.Proposals = append(ret.Body.Proposals, DecodedProposal{
+			Deposit:       proposal.Deposit(),
+			RewardAccount: proposal.RewardAccount().String(),
+			Type:          govActionTypeName(proposal.GovAction()),
+			Anchor:        decodeAnchor(proposal.Anchor()),
+			GovAction:     ledgerJSON(proposal.GovAction()),
+		})
+	}
+	if w := tx.Witnesses(); w != nil {
+		ret.Witnesses = decodeWitnesses(w)
+	}
+	if metadata := tx.Metadata(); metadata != nil {
+		ret.Metadata = metadatumJSON(metadata)
+	}
+	return ret, nil
+}
+
+func decodeOutput(output lcommon.TransactionOutput) DecodedOutput {
+	ret := DecodedOutput{
+		Address:   output.Address().String(),
+		Amount:    output.Amount(),
+		Assets:    output.Assets(),
+		DatumHash: output.DatumHash(),
+	}
+	if ret.Amount == nil {
+		ret.Amount = new(big.Int)
+	}
+	if datum := output.Datum(); datum != nil {
+		data := decodePlutusData(datum.Cbor())
+		ret.Datum = &data
+	}
+	if script := output.ScriptRef(); script != nil {
+		decoded := decodeScript(script)
+		ret.ScriptRef = &decoded
+	}
+	return ret
+}
+
+// decodePlutusData renders Plutus data CBOR. The JSON is left out if the
+// CBOR cannot be decoded.
+func decodePlutusData(dataCbor []byte) PlutusData {
+	ret := PlutusData{Cbor: hex.EncodeToString(dataCbor)}
+	var value cbor.Value
+	if _, err := cbor.Decode(dataCbor, &value); err != nil {
+		return ret
+	}
+	// cbor.Value renders as {"cbor": ..., "json": ...}
+	var rendered struct {
+		Json json.RawMessage `json:"json"`
+	}
+	if out, err := json.Marshal(value); err == nil && json.Unmarshal(out, &rendered) == nil {
+		ret.Json = rendered.Json
+	}
+	return ret
+}
+
+func decodeScript(script lcommon.Script) DecodedScript {
+	ret := DecodedScript{Hash: script.Hash().String()}
+	switch s := script.(type) {
+	case lcommon.NativeScript:
+		ret.Type = "native"
+		ret.Script = nativeScriptJSON(&s)
+	case *lcommon.NativeScript:
+		ret.Type = "native"
+		ret.Script = nativeScriptJSON(s)
+	case lcommon.PlutusV1Script, *lcommon.PlutusV1Script:
+		ret.Type = "plutus_v1"
+	case lcommon.PlutusV2Script, *lcommon.PlutusV2Script:
+		ret.Type = "plutus_v2"
+	case lcommon.PlutusV3Script, *lcommon.PlutusV3Script:
+		ret.Type = "plutus_v3"
+	default:
+		ret.Type = "unknown"
+	}
+	if ret.Type != "native" {
+		ret.Cbor = hex.EncodeToString(script.RawScriptBytes())
+	}
+	return ret
+}
+
+// nativeScriptJSON renders a native script in the cardano-cli JSON format.
+func nativeScriptJSON(script *lcommon.NativeScript) any {
+	children := func(scripts []lcommon.NativeScript) []any {
+		ret := make([]any, 0, len(scripts))
+		for i := range scripts {
+			ret = append(ret, nativeScriptJSON(&scripts[i]))
+		}
+		return ret
+	}
+	switch s := script.Item().(type) {
+	case *lcommon.NativeScriptPubkey:
+		return map[string]any{"type": "sig", "keyHash": hex.EncodeToString(s.Hash)}
+	case *lcommon.NativeScriptAll:
+		return map[string]any{"type": "all", "scripts": children(s.Scripts)}
+	case *lcommon.NativeScriptAny:
+		return map[string]any{"type": "any", "scripts": children(s.Scripts)}
+	case *lcommon.NativeScriptNofK:
+		return map[string]any{"type": "atLeast", "required": s.N, "scripts": children(s.Scripts)}
+	case *lcommon.NativeScriptInvalidBefore:
+		return map[string]any{"type": "after", "slot": s.Slot}
+	case *lcommon.NativeScriptInvalidHereafter:
+		return map[string]any{"type": "before", "slot": s.Slot}
+	default:
+		return nil
+	}
+}
+
+func decodeWitnesses(w lcommon.TransactionWitnessSet) DecodedWitnesses {
+	var ret DecodedWitnesses
+	for _, vkey := range w.Vkey() {
+		ret.Vkey = append(ret.Vkey, DecodedVkeyWitness{
+			KeyHash:   lcommon.Blake2b224Hash(vkey.Vkey),
+			Vkey:      hex.EncodeToString(vkey.Vkey),
+			Signature: hex.EncodeToString(vkey.Signature),
+		})
+	}
+	for _, bootstrap := range w.Bootstrap() {
+		ret.Bootstrap = append(ret.Bootstrap, DecodedBootstrapWitness{
+			PublicKey:  hex.EncodeToString(bootstrap.PublicKey),
+			Signature:  hex.EncodeToString(bootstrap.Signature),
+			ChainCode:  hex.EncodeToString(bootstrap.ChainCode),
+			Attributes: hex.EncodeToString(bootstrap.Attributes),
+		})
+	}
+	for _, script := range w.NativeScripts() {
+		ret.Scripts = append(ret.Scripts, decodeScript(script))
+	}
+	for _, script := range w.PlutusV1Scripts() {
+		ret.Scripts = append(ret.Scripts, decodeScript(script))
+	}
+	for _, script := range w.PlutusV2Scripts() {
+		ret.Scripts = append(ret.Scripts, decodeScript(script))
+	}
+	for _, script := range w.PlutusV3Scripts() {
+		ret.Scripts = append(ret.Scripts, decodeScript(script))
+	}
+	for _, datum := range w.PlutusData() {
+		data := decodePlutusData(datum.Cbor())
+		data.Hash = lcommon.Blake2b256Hash(datum.Cbor()).String()
+		ret.PlutusData = append(ret.PlutusData, data)
+	}
+	if w.Redeemers() != nil {
+		for key, value := range w.Redeemers().Iter() {
+			ret.Redeemers = append(ret.Redeemers, DecodedRedeemer{
+				Tag:     key.Tag,
+				Index:   key.Index,
+				Data:    decodePlutusData(value.Data.Cbor()),
+				ExUnits: value.ExUnits,
+			})
+		}
+		slices.SortFunc(ret.Redeemers, func(a, b DecodedRedeemer) int {
+			return cmp.Or(cmp.Compare(a.Tag, b.Tag), cmp.Compare(a.Index, b.Index))
+		})
+	}
+	return ret
+}
+
+func decodeVotes(procedures lcommon.VotingProcedures) []DecodedVote {
+	var ret []DecodedVote
+	for voter, votes := range procedures {
+		if voter == nil {
+			continue
+		}
+		for actionId, procedure := range votes {
+			vote := DecodedVote{
+				VoterType: voterTypeName(voter),
+				Voter:     voter.String(),
+				Vote:      voteName(procedure.Vote),
+			}
+			if actionId != nil {
+				vote.GovActionId = fmt.Sprintf("%x#%d", actionId.TransactionId, actionId.GovActionIdx)
+			}
+			if procedure.Anchor != nil {
+				anchor := decodeAnchor(*procedure.Anchor)
+				vote.Anchor = &anchor
+			}
+			ret = append(ret, vote)
+		}
+	}
+	// Votes are a map, so sort them for a stable result
+	slices.SortFunc(ret, func(a, b DecodedVote) int {
+		return cmp.Or(strings.Compare(a.Voter, b.Voter), strings.Compare(a.GovActionId, b.GovActionId))
+	})
+	return ret
+}
+
+func decodeAnchor(anchor lcommon.GovAnchor) DecodedAnchor {
+	return DecodedAnchor{
+		Url:      anchor.Url,
+		DataHash: hex.EncodeToString(anchor.DataHash[:]),
+	}
+}
+
+// ledgerJSON renders a gouroboros ledger value with encoding/json, or returns
+// nil for values it cannot render, such as maps keyed by credentials.
+func ledgerJSON(v any) json.RawMessage {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// metadatumJSON renders transaction metadata in the cardano-cli "no schema"
+// JSON format.
+func metadatumJSON(m lcommon.TransactionMetadatum) any {
+	switch v := m.(type) {
+	case lcommon.MetaInt:
+		return json.Number(v.Value.String())
+	case lcommon.MetaText:
+		return v.Value
+	case lcommon.MetaBytes:
+		return "0x" + hex.EncodeToString(v.Value)
+	case lcommon.MetaList:
+		ret := make([]any, 0, len(v.Items))
+		for _, item := range v.Items {
+			ret = append(ret, metadatumJSON(item))
+		}
+		return ret
+	case lcommon.MetaMap:
+		obj := make(map[string]any, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			var key string
+			switch k := pair.Key.(type) {
+			case lcommon.MetaInt:
+				key = k.Value.String()
+			case lcommon.MetaText:
+				key = k.Value
+			default:
+				return metaPairsJSON(v.Pairs)
+			}
+			obj[key] = metadatumJSON(pair.Value)
+		}
+		return obj
+	default:
+		return nil
+	}
+}
+
+// metaPairsJSON renders a metadata map with keys that cannot be JSON object
+// keys as a list of k/v pairs.
+func metaPairsJSON(pairs []lcommon.MetaPair) []any {
+	ret := make([]any, 0, len(pairs))
+	for _, pair := range pairs {
+		ret = append(ret, map[string]any{
+			"k": metadatumJSON(pair.Key),
+			"v": metadatumJSON(pair.Value),
+		})
+	}
+	return ret
+}
+
+// nonZero returns nil for a nil or zero amount, so optional body fields are
+// left out of the JSON.
+func nonZero(amount *big.Int) *big.Int {
+	if amount == nil || amount.Sign() == 0 {
+		return nil
+	}
+	return amount
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func TestReadTxBytes(t *testing.T) {
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	txHex := hex.EncodeToString(txBytes)
+	testCases := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "cbor", data: string(txBytes)},
+		{name: "hex", data: txHex + "\n"},
+		{
+			name: "text envelope",
+			data: `{"type": "Witnessed Tx ConwayEra", "description": "Ledger Cddl Format", "cborHex": "` + txHex + `"}`,
+		},
+		{
+			name:    "text envelope without cbor",
+			data:    `{"type": "Witnessed Tx ConwayEra"}`,
+			wantErr: "invalid TextEnvelope: missing cborHex",
+		},
+		{
+			name:    "invalid text envelope",
+			data:    `{"cborHex": 1}`,
+			wantErr: "invalid TextEnvelope",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReadTxBytes([]byte(tc.data))
+			if tc.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !bytes.Equal(got, txBytes) {
+				t.Errorf("expected transaction CBOR %x, got %x", txBytes, got)
+			}
+		})
+	}
+}
+
+func TestDecodeTx(t *testing.T) {
+	keyHash := bytes.Repeat([]byte{0x11}, 28)
+	rewardAccount := append([]byte{0xe0}, keyHash...)
+	datum := mustDecodeHex(t, "d8799f182aff")
+	body := buildMinimalConwayBody()
+	body[1] = []map[uint]any{{
+		0: append([]byte{0x60}, make([]byte, 28)...),
+		1: uint64(2_000_000),
+		2: []any{uint(1), gocbor.WrappedCbor(datum)},
+	}}
+	body[4] = []any{[]any{uint(2), []any{uint(0), keyHash}, bytes.Repeat([]byte{0x22}, 28)}}
+	body[5] = map[gocbor.ByteString]uint64{gocbor.NewByteString(rewardAccount): 5_000_000}
+	// {[drep key hash voter, hash]: {[tx ID, 0]: [yes, null]}}
+	body[19] = gocbor.RawMessage(mustDecodeHex(t,
+		"a1"+"8202581c"+strings.Repeat("33", 28)+"a1"+"825820"+strings.Repeat("44", 32)+"00"+"8201f6"))
+	// Info action with an anchor
+	body[20] = []any{[]any{
+		uint64(100_000_000_000),
+		rewardAccount,
+		[]any{uint(6)},
+		[]any{"https://example.com/proposal.json", bytes.Repeat([]byte{0x55}, 32)},
+	}}
+	nativeScript := []any{uint(0), keyHash}
+	witnesses := map[uint]any{
+		0: []any{[]any{bytes.Repeat([]byte{0x66}, 32), bytes.Repeat([]byte{0x77}, 64)}},
+		1: []any{nativeScript},
+	}
+	metadata := map[uint]any{
+		674: map[string]any{"msg": []any{"hello"}},
+		1:   map[any]any{uint64(1): []byte{0xca, 0xfe}},
+	}
+	txBytes := mustEncode(t, []any{
+		gocbor.RawMessage(mustEncode(t, body)),
+		gocbor.RawMessage(mustEncode(t, witnesses)),
+		true,
+		metadata,
+	})
+
+	decoded, err := DecodeTx(txBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if decoded.Era != "Conway" || decoded.Size != len(txBytes) || !decoded.IsValid {
+		t.Errorf("unexpected era %q, size %d or validity %v", decoded.Era, decoded.Size, decoded.IsValid)
+	}
+
+	output := decoded.Body.Outputs[0]
+	if !strings.HasPrefix(output.Address, "addr_test1") {
+		t.Errorf("expected a bech32 testnet address, got %q", output.Address)
+	}
+	if output.Datum == nil || output.Datum.Cbor != "d8799f182aff" ||
+		string(output.Datum.Json) != `{"constructor":0,"fields":[{"int":42}]}` {
+		t.Errorf("unexpected inline datum %+v", output.Datum)
+	}
+
+	if len(decoded.Body.Certificates) != 1 || decoded.Body.Certificates[0].Type != "stake_delegation" ||
+		decoded.Body.Certificates[0].Certificate == nil {
+		t.Errorf("unexpected certificates %+v", decoded.Body.Certificates)
+	}
+	if len(decoded.Body.Withdrawals) != 1 || !strings.HasPrefix(decoded.Body.Withdrawals[0].Address, "stake_test1") ||
+		decoded.Body.Withdrawals[0].Amount.Uint64() != 5_000_000 {
+		t.Errorf("unexpected withdrawals %+v", decoded.Body.Withdrawals)
+	}
+
+	wantVote := DecodedVote{
+		VoterType:   "drep",
+		GovActionId: strings.Repeat("44", 32) + "#0",
+		Vote:        "yes",
+	}
+	if len(decoded.Body.Votes) != 1 {
+		t.Fatalf("expected 1 vote, got %d", len(decoded.Body.Votes))
+	}
+	vote := decoded.Body.Votes[0]
+	vote.Voter = ""
+	if vote != wantVote {
+		t.Errorf("expected vote %+v, got %+v", wantVote, vote)
+	}
+	wantProposal := DecodedProposal{
+		Deposit: 100_000_000_000,
+		Type:    "info",
+		Anchor:  DecodedAnchor{Url: "https://example.com/proposal.json", DataHash: strings.Repeat("55", 32)},
+	}
+	if len(decoded.Body.Proposals) != 1 {
+		t.Fatalf("expected 1 proposal, got %d", len(decoded.Body.Proposals))
+	}
+	proposal := decoded.Body.Proposals[0]
+	if proposal.Deposit != wantProposal.Deposit || proposal.Type != wantProposal.Type ||
+		proposal.Anchor != wantProposal.Anchor || !strings.HasPrefix(proposal.RewardAccount, "stake_test1") {
+		t.Errorf("expected proposal %+v, got %+v", wantProposal, proposal)
+	}
+
+	if len(decoded.Witnesses.Vkey) != 1 ||
+		decoded.Witnesses.Vkey[0].KeyHash != lcommon.Blake2b224Hash(bytes.Repeat([]byte{0x66}, 32)) {
+		t.Errorf("unexpected vkey witnesses %+v", decoded.Witnesses.Vkey)
+	}
+	if len(decoded.Witnesses.Scripts) != 1 {
+		t.Fatalf("expected 1 script, got %d", len(decoded.Witnesses.Scripts))
+	}
+	script, err := json.Marshal(decoded.Witnesses.Scripts[0].Script)
+	if err != nil {
+		t.Fatalf("encode script: %s", err)
+	}
+	if want := `{"keyHash":"` + hex.EncodeToString(keyHash) + `","type":"sig"}`; string(script) != want {
+		t.Errorf("expected native script %s, got %s", want, script)
+	}
+
+	gotMetadata, err := json.Marshal(decoded.Metadata)
+	if err != nil {
+		t.Fatalf("encode metadata: %s", err)
+	}
+	if want := `{"1":{"1":"0xcafe"},"674":{"msg":["hello"]}}`; string(gotMetadata) != want {
+		t.Errorf("expected metadata %s, got %s", want, gotMetadata)
+	}
+}
+
+func TestDecodeTx_InvalidCBOR(t *testing.T) {
+	if _, err := DecodeTx([]byte("not-valid-cbor")); err == nil {
+		t.Error("expected error for invalid CBOR, got nil")
+	}
+}
+
+func TestMetadatumJSON_NonStringKeys(t *testing.T) {
+	metadatum := lcommon.MetaMap{Pairs: []lcommon.MetaPair{{
+		Key:   lcommon.MetaBytes{Value: []byte{0x01}},
+		Value: lcommon.MetaText{Value: "one"},
+	}}}
+	got, err := json.Marshal(metadatumJSON(metadatum))
+	if err != nil {
+		t.Fatalf("encode metadata: %s", err)
+	}
+	if want := `[{"k":"0x01","v":"one"}]`; string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// EraMismatchError is returned by CheckTxEra for a transaction that is not
+// encoded for the current era of the node.
+type EraMismatchError struct {
+	TxEra   ledger.Era
+	NodeEra ledger.Era
+	// Accepted are the eras the node accepts transactions for.
+	Accepted []ledger.Era
+}
+
+func (e *EraMismatchError) Error() string {
+	names := make([]string, 0, len(e.Accepted))
+	for _, era := range e.Accepted {
+		names = append(names, era.Name)
+	}
+	return fmt.Sprintf(
+		"transaction is encoded for the %s era, but the node is in the %s era (accepted eras: %s)",
+		e.TxEra.Name,
+		e.NodeEra.Name,
+		strings.Join(names, ", "),
+	)
+}
+
+// CheckTxEra checks that a transaction of type txType, as returned by
+// ledger.DetermineTransactionType, can be submitted to a node in nodeEra, and
+// returns the transaction type to submit it as. The node only accepts
+// transactions of its current era. As DetermineTransactionType returns the
+// latest era a transaction decodes in, a transaction that also decodes in the
+// node era is submitted as such. Otherwise an *EraMismatchError is returned.
+func CheckTxEra(txRawBytes []byte, txType uint, nodeEra ledger.Era) (uint, error) {
+	if txType == uint(nodeEra.Id) {
+		return txType, nil
+	}
+	if _, err := ledger.NewTransactionFromCbor(uint(nodeEra.Id), txRawBytes); err == nil {
+		return uint(nodeEra.Id), nil
+	}
+	return txType, &EraMismatchError{
+		// #nosec G115 -- transaction types are era IDs
+		TxEra:    ledger.GetEraById(uint8(txType)),
+		NodeEra:  nodeEra,
+		Accepted: []ledger.Era{nodeEra},
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// buildShelleyTx encodes a pre-Alonzo [body, witnesses, metadata] transaction.
+func buildShelleyTx(t *testing.T) []byte {
+	t.Helper()
+	addr := append([]byte{0x60}, make([]byte, 28)...)
+	body := map[uint]any{
+		0: [][]any{{make([]byte, 32), uint32(0)}},
+		1: [][]any{{addr, uint64(1_000_000_000)}},
+		2: uint64(100_000),
+		3: uint64(1_000),
+	}
+	return mustEncode(t, []any{body, map[uint]any{}, nil})
+}
+
+func TestCheckTxEra(t *testing.T) {
+	conway := ledger.GetEraById(ledger.EraIdConway)
+	babbage := ledger.GetEraById(ledger.EraIdBabbage)
+
+	testCases := []struct {
+		name     string
+		tx       []byte
+		nodeEra  ledger.Era
+		wantType uint
+		wantErr  string
+	}{
+		{
+			name:     "same era",
+			tx:       buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{}),
+			nodeEra:  conway,
+			wantType: ledger.TxTypeConway,
+		},
+		{
+			name:     "decodes in the node era",
+			tx:       buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{}),
+			nodeEra:  babbage,
+			wantType: ledger.TxTypeBabbage,
+		},
+		{
+			name:    "older than the node era",
+			tx:      buildShelleyTx(t),
+			nodeEra: conway,
+			wantErr: "transaction is encoded for the Shelley era, but the node is in the Conway era (accepted eras: Conway)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txType, err := ledger.DetermineTransactionType(tc.tx)
+			if err != nil {
+				t.Fatalf("unexpected error determining transaction type: %s", err)
+			}
+			gotType, err := CheckTxEra(tc.tx, txType, tc.nodeEra)
+			if tc.wantErr != "" {
+				var mismatch *EraMismatchError
+				if !errors.As(err, &mismatch) {
+					t.Fatalf("expected EraMismatchError, got %v", err)
+				}
+				if err.Error() != tc.wantErr {
+					t.Errorf("expected error %q, got %q", tc.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotType != tc.wantType {
+				t.Errorf("expected transaction type %d, got %d", tc.wantType, gotType)
+			}
+		})
+	}
+}
+
+func TestSubmitTx_EraMismatch(t *testing.T) {
+	cfg := &Config{
+		CurrentEra: func(bool) (ledger.Era, error) {
+			return ledger.GetEraById(ledger.EraIdConway), nil
+		},
+	}
+	// The era check fails before the node is dialed
+	_, err := SubmitTx(cfg, buildShelleyTx(t))
+	var mismatch *EraMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected EraMismatchError, got %v", err)
+	}
+	if mismatch.TxEra.Id != ledger.EraIdShelley {
+		t.Errorf("expected Shelley transaction era, got %s", mismatch.TxEra.Name)
+	}
+}
+
+func TestSubmitTx_StaleEra(t *testing.T) {
+	var refreshed bool
+	cfg := &Config{
+		// The cached era is out of date and refused by the check
+		CurrentEra: func(refresh bool) (ledger.Era, error) {
+			if !refresh {
+				return ledger.GetEraById(ledger.EraIdConway), nil
+			}
+			refreshed = true
+			return ledger.GetEraById(ledger.EraIdShelley), nil
+		},
+	}
+	_, err := SubmitTx(cfg, buildShelleyTx(t))
+	if !refreshed {
+		t.Error("expected the era to be refreshed")
+	}
+	var mismatch *EraMismatchError
+	if errors.As(err, &mismatch) {
+		t.Errorf("expected the refreshed era to be used, got %s", err)
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/common/script"
+	"github.com/blinklabs-io/plutigo/cek"
+	"github.com/blinklabs-io/plutigo/data"
+	"github.com/blinklabs-io/plutigo/lang"
+	"github.com/blinklabs-io/plutigo/syn"
+)
+
+// RedeemerEvaluation is the result of running the script of a redeemer.
+type RedeemerEvaluation struct {
+	Tag        lcommon.RedeemerTag `json:"tag"`
+	Index      uint32              `json:"index"`
+	ScriptHash string              `json:"scriptHash,omitempty"`
+	// Language is "PlutusV1", "PlutusV2" or "PlutusV3".
+	Language string `json:"language,omitempty"`
+	// Budget is the execution units declared by the redeemer, and ExUnits
+	// those consumed by the script.
+	Budget  lcommon.ExUnits `json:"budget"`
+	ExUnits lcommon.ExUnits `json:"exUnits"`
+	Success bool            `json:"success"`
+	// WithinBudget is set when the script succeeds within the budget
+	// declared by the redeemer, so the transaction can be submitted without
+	// updating it.
+	WithinBudget bool   `json:"withinBudget"`
+	Error        string `json:"error,omitempty"`
+	// Traces are the messages logged by the script with the trace builtin.
+	Traces []string `json:"traces,omitempty"`
+}
+
+// UnresolvedInputsError is returned by EvaluateTx when spent or reference
+// inputs of a transaction are not in the UTxO set.
+type UnresolvedInputsError struct {
+	Inputs []string
+}
+
+func (e *UnresolvedInputsError) Error() string {
+	return "inputs not found in the UTxO set: " + strings.Join(e.Inputs, ", ")
+}
+
+// EvaluateTx runs the Plutus script of each redeemer of a transaction, with
+// the script context built from its spent and reference inputs resolved by
+// resolve. Scripts run against the cost models and protocol version of
+// pparams, within the transaction limit rather than the budget declared by
+// their redeemer, which is often a placeholder, so that this can be used to
+// compute execution units. slotState converts the validity interval to the
+// POSIX times of the script context. The results are ordered by redeemer tag
+// and index.
+func EvaluateTx(
+	txRawBytes []byte,
+	pparams *ProtocolParams,
+	slotState lcommon.SlotState,
+	resolve UTxOResolver,
+) ([]RedeemerEvaluation, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	w := tx.Witnesses()
+	if w == nil || w.Redeemers() == nil {
+		return nil, nil
+	}
+	type redeemer struct {
+		key   lcommon.RedeemerKey
+		value lcommon.RedeemerValue
+	}
+	var redeemers []redeemer
+	for key, value := range w.Redeemers().Iter() {
+		redeemers = append(redeemers, redeemer{key, value})
+	}
+	if len(redeemers) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(redeemers, func(a, b redeemer) int {
+		return cmp.Or(cmp.Compare(a.key.Tag, b.key.Tag), cmp.Compare(a.key.Index, b.key.Index))
+	})
+
+	e, err := newScriptEval(tx, pparams, slotState, resolve)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]RedeemerEvaluation, 0, len(redeemers))
+	for _, r := range redeemers {
+		ret = append(ret, e.evaluate(r.key, r.value))
+	}
+	return ret, nil
+}
+
+// scriptEval holds the transaction state redeemers are evaluated against.
+// The TxInfo of each Plutus version is built when first needed.
+type scriptEval struct {
+	tx             ledger.Transaction
+	pparams        *ProtocolParams
+	slotState      lcommon.SlotState
+	resolvedInputs []lcommon.Utxo
+	resolvedMap    map[string]lcommon.Utxo
+	sortedInputs   []lcommon.TransactionInput
+	scripts        map[lcommon.ScriptHash]lcommon.Script
+	witnessDatums  map[lcommon.Blake2b256]*lcommon.Datum
+	txInfo         map[lang.LanguageVersion]script.TxInfo
+}
+
+func newScriptEval(
+	tx ledger.Transaction,
+	pparams *ProtocolParams,
+	slotState lcommon.SlotState,
+	resolve UTxOResolver,
+) (*scriptEval, error) {
+	e := &scriptEval{
+		tx:            tx,
+		pparams:       pparams,
+		slotState:     slotState,
+		resolvedMap:   make(map[string]lcommon.Utxo),
+		sortedInputs:  script.SortInputs(tx.Inputs()),
+		scripts:       make(map[lcommon.ScriptHash]lcommon.Script),
+		witnessDatums: make(map[lcommon.Blake2b256]*lcommon.Datum),
+		txInfo:        make(map[lang.LanguageVersion]script.TxInfo),
+	}
+	inputs := slices.Concat(tx.Inputs(), tx.ReferenceInputs())
+	utxos, err := resolve(inputs)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, input := range inputs {
+		output, ok := utxos[input.String()]
+		if !ok {
+			missing = append(missing, input.String())
+			continue
+		}
+		utxo := lcommon.Utxo{Id: input, Output: output}
+		e.resolvedInputs = append(e.resolvedInputs, utxo)
+		e.resolvedMap[input.String()] = utxo
+		if ref := output.ScriptRef(); ref != nil {
+			e.scripts[ref.Hash()] = ref
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &UnresolvedInputsError{Inputs: missing}
+	}
+
+	w := tx.Witnesses()
+	for _, s := range w.PlutusV1Scripts() {
+		e.scripts[s.Hash()] = s
+	}
+	for _, s := range w.PlutusV2Scripts() {
+		e.scripts[s.Hash()] = s
+	}
+	for _, s := range w.PlutusV3Scripts() {
+		e.scripts[s.Hash()] = s
+	}
+	plutusData := w.PlutusData()
+	for i := range plutusData {
+		e.witnessDatums[plutusData[i].Hash()] = &plutusData[i]
+	}
+	return e, nil
+}
+
+func (e *scriptEval) evaluate(key lcommon.RedeemerKey, value lcommon.RedeemerValue) RedeemerEvaluation {
+	ret := RedeemerEvaluation{
+		Tag:    key.Tag,
+		Index:  key.Index,
+		Budget: value.ExUnits,
+	}
+	fail := func(format string, args ...any) RedeemerEvaluation {
+		ret.Error = fmt.Sprintf(format, args...)
+		return ret
+	}
+
+	mint := e.tx.AssetMint()
+	if mint == nil {
+		mint = &lcommon.MultiAsset[lcommon.MultiAssetTypeMint]{}
+	}
+	purpose := script.BuildScriptPurpose(
+		key,
+		e.resolvedMap,
+		e.sortedInputs,
+		*mint,
+		e.tx.Certificates(),
+		e.tx.Withdrawals(),
+		e.tx.VotingProcedures(),
+		e.tx.ProposalProcedures(),
+		e.witnessDatums,
+	)
+	if purpose == nil {
+		return fail("redeemer does not point to anything in the transaction")
+	}
+	scriptHash := purpose.ScriptHash()
+	ret.ScriptHash = scriptHash.String()
+	plutusScript, ok := e.scripts[scriptHash]
+	if !ok {
+		return fail("no witness or reference script with hash %s", scriptHash.String())
+	}
+
+	var version lang.LanguageVersion
+	var rawScript []byte
+	switch s := plutusScript.(type) {
+	case lcommon.PlutusV1Script:
+		version, rawScript, ret.Language = lang.LanguageVersionV1, s, "PlutusV1"
+	case lcommon.PlutusV2Script:
+		version, rawScript, ret.Language = lang.LanguageVersionV2, s, "PlutusV2"
+	case lcommon.PlutusV3Script:
+		version, rawScript, ret.Language = lang.LanguageVersionV3, s, "PlutusV3"
+	default:
+		return fail("script %s is not a Plutus script", scriptHash.String())
+	}
+
+	txInfo, err := e.buildTxInfo(version)
+	if err != nil {
+		return fail("failure building script context: %s", err)
+	}
+	var args []data.PlutusData
+	if version == lang.LanguageVersionV3 {
+		ctx := script.NewScriptContextV3(txInfo, script.Redeemer{
+			Tag:     key.Tag,
+			Index:   key.Index,
+			Data:    value.Data.Data,
+			ExUnits: value.ExUnits,
+		}, purpose)
+		args = append(args, ctx.ToPlutusData())
+	} else {
+		// Spending scripts before PlutusV3 take the datum as an argument
+		if spend, ok := purpose.(script.ScriptPurposeSpending); ok {
+			if spend.Datum == nil {
+				return fail("missing datum for spent input %s", spend.Input.Id.String())
+			}
+			args = append(args, spend.Datum)
+		}
+		ctx := script.NewScriptContextV1V2(txInfo, purpose)
+		args = append(args, value.Data.Data, ctx.ToPlutusData())
+	}
+
+	var budget lcommon.ExUnits
+	if e.pparams.MaxTxExecutionUnits != nil {
+		budget = *e.pparams.MaxTxExecutionUnits
+	}
+	consumed, traces, err := e.run(version, e.pparams.CostModels[ret.Language], rawScript, args, budget)
+	ret.ExUnits = consumed
+	ret.Traces = traces
+	if err != nil {
+		return fail("%s", err)
+	}
+	ret.Success = true
+	ret.WithinBudget = consumed.Memory <= value.ExUnits.Memory && consumed.Steps <= value.ExUnits.Steps
+	return ret
+}
+
+// buildTxInfo returns the TxInfo of the transaction for a Plutus version.
+func (e *scriptEval) buildTxInfo(version lang.LanguageVersion) (script.TxInfo, error) {
+	if txInfo, ok := e.txInfo[version]; ok {
+		return txInfo, nil
+	}
+	var txInfo script.TxInfo
+	switch version {
+	case lang.LanguageVersionV1:
+		v1, err := script.NewTxInfoV1FromTransaction(e.slotState, e.tx, e.resolvedInputs)
+		if err != nil {
+			return nil, err
+		}
+		v1.ProtocolMajor = e.pparams.ProtocolVersion.Major
+		txInfo = v1
+	case lang.LanguageVersionV2:
+		v2, err := script.NewTxInfoV2FromTransaction(e.slotState, e.tx, e.resolvedInputs)
+		if err != nil {
+			return nil, err
+		}
+		v2.ProtocolMajor = e.pparams.ProtocolVersion.Major
+		txInfo = v2
+	default:
+		v3, err := script.NewTxInfoV3FromTransaction(e.slotState, e.tx, e.resolvedInputs)
+		if err != nil {
+			return nil, err
+		}
+		txInfo = v3
+	}
+	e.txInfo[version] = txInfo
+	return txInfo, nil
+}
+
+// run applies a script to its arguments and runs it on the CEK machine within
+// budget, using the default costs for parameters missing from costModel. It
+// returns the consumed execution units and the script traces.
+func (e *scriptEval) run(
+	version lang.LanguageVersion,
+	costModel []int64,
+	rawScript []byte,
+	args []data.PlutusData,
+	budget lcommon.ExUnits,
+) (lcommon.ExUnits, []string, error) {
+	var consumed lcommon.ExUnits
+	evalContext, err := cek.NewEvalContext(
+		version,
+		cek.ProtoVersion{
+			Major: e.pparams.ProtocolVersion.Major,
+			Minor: e.pparams.ProtocolVersion.Minor,
+		},
+		costModel,
+	)
+	if err != nil {
+		return consumed, nil, fmt.Errorf("failure building evaluation context: %w", err)
+	}
+	// Scripts are CBOR byte strings wrapping the flat-encoded program
+	var innerScript []byte
+	if _, err := cbor.Decode(rawScript, &innerScript); err != nil {
+		return consumed, nil, fmt.Errorf("failure decoding script: %w", err)
+	}
+	program, err := syn.Decode[syn.DeBruijn](innerScript)
+	if err != nil {
+		return consumed, nil, fmt.Errorf("failure decoding script: %w", err)
+	}
+	term := program.Term
+	for _, arg := range args {
+		term = &syn.Apply[syn.DeBruijn]{
+			Function: term,
+			Argument: &syn.Constant{Con: &syn.Data{Inner: arg}},
+		}
+	}
+
+	machineBudget := cek.DefaultExBudget
+	if budget.Memory > 0 || budget.Steps > 0 {
+		machineBudget = cek.ExBudget{Mem: budget.Memory, Cpu: budget.Steps}
+	}
+	machine := cek.NewMachine[syn.DeBruijn](version, 200, evalContext)
+	machine.ExBudget = machineBudget
+	_, runErr := machine.Run(term)
+	used := machineBudget.Sub(&machine.ExBudget)
+	consumed = lcommon.ExUnits{Memory: used.Mem, Steps: used.Cpu}
+	traces := slices.Clone(machine.Logs)
+	if runErr != nil {
+		return consumed, traces, runErr
+	}
+	return consumed, traces, nil
+}
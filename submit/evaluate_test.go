@@ -0,0 +1,378 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/common/script"
+	"github.com/blinklabs-io/plutigo/lang"
+	"github.com/blinklabs-io/plutigo/syn"
+)
+
+const (
+	// datumCheckScript is a PlutusV2 spending script that succeeds when the
+	// datum is the integer 7
+	datumCheckScript = `(program 1.0.0 (lam datum (lam redeemer (lam ctx (force [(force (builtin ifThenElse)) [(builtin equalsData) datum (con data (I 7))] (delay (con unit ())) (delay (error))])))))`
+	// traceSucceedsScript traces a message and returns unit
+	traceSucceedsScript = `(program 1.1.0 (lam ctx [(force (builtin trace)) (con string "checked") (con unit ())]))`
+	// traceFailsScript traces a message and then fails
+	traceFailsScript = `(program 1.1.0 (lam ctx (force [(force (builtin trace)) (con string "rejected") (delay (error))])))`
+)
+
+// compilePlutusV3 parses a UPLC program and returns it as a PlutusV3 script,
+// a CBOR byte string wrapping the flat-encoded program.
+func compilePlutusV3(t *testing.T, program string) lcommon.PlutusV3Script {
+	t.Helper()
+	return lcommon.PlutusV3Script(compileScript(t, program))
+}
+
+// compileScript parses a UPLC program and returns it as a CBOR byte string
+// wrapping the flat-encoded program.
+func compileScript(t *testing.T, program string) []byte {
+	t.Helper()
+	named, err := syn.Parse(program)
+	if err != nil {
+		t.Fatalf("parse script: %v", err)
+	}
+	debruijn, err := syn.NameToDeBruijn(named)
+	if err != nil {
+		t.Fatalf("convert script: %v", err)
+	}
+	flat, err := syn.Encode(debruijn)
+	if err != nil {
+		t.Fatalf("encode script: %v", err)
+	}
+	return mustEncode(t, flat)
+}
+
+// scriptAddress returns a mainnet enterprise address paying to a script.
+func scriptAddress(t *testing.T, s lcommon.Script) lcommon.Address {
+	t.Helper()
+	hash := s.Hash()
+	addr, err := lcommon.NewAddressFromBytes(append([]byte{0x71}, hash[:]...))
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	return addr
+}
+
+// buildSpendTx builds a transaction spending one script input, with a spend
+// redeemer declaring exUnits and the witness scripts.
+func buildSpendTx(t *testing.T, exUnits lcommon.ExUnits, refInput bool, scripts ...lcommon.PlutusV3Script) []byte {
+	t.Helper()
+	body := buildMinimalConwayBody()
+	if refInput {
+		body[18] = [][]any{{make([]byte, 32), uint32(1)}}
+	}
+	witnesses := map[uint]any{
+		5: [][]any{{uint8(lcommon.RedeemerTagSpend), uint32(0), int64(42), []int64{exUnits.Memory, exUnits.Steps}}},
+	}
+	if len(scripts) > 0 {
+		raw := make([][]byte, 0, len(scripts))
+		for _, s := range scripts {
+			raw = append(raw, s)
+		}
+		witnesses[7] = raw
+	}
+	return buildConwayTx(t, body, witnesses)
+}
+
+// fixtureResolver resolves inputs from a fixed UTxO set.
+func fixtureResolver(utxos map[string]ledger.TransactionOutput) UTxOResolver {
+	return func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		ret := make(map[string]ledger.TransactionOutput)
+		for _, input := range inputs {
+			if output, ok := utxos[input.String()]; ok {
+				ret[input.String()] = output
+			}
+		}
+		return ret, nil
+	}
+}
+
+func testEvalParams() *ProtocolParams {
+	return &ProtocolParams{
+		ProtocolVersion:     ProtocolVersion{Major: 10},
+		MaxTxExecutionUnits: &lcommon.ExUnits{Memory: 14_000_000, Steps: 10_000_000_000},
+	}
+}
+
+var (
+	spentInput = strings.Repeat("00", 32) + "#0"
+	refInput   = strings.Repeat("00", 32) + "#1"
+)
+
+func TestEvaluateTx(t *testing.T) {
+	succeeds := compilePlutusV3(t, traceSucceedsScript)
+	fails := compilePlutusV3(t, traceFailsScript)
+	budget := lcommon.ExUnits{Memory: 1_000_000, Steps: 500_000_000}
+
+	testCases := []struct {
+		name    string
+		script  lcommon.PlutusV3Script
+		success bool
+		trace   string
+	}{
+		{"succeeds", succeeds, true, "checked"},
+		{"fails", fails, false, "rejected"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolve := fixtureResolver(map[string]ledger.TransactionOutput{
+				spentInput: babbage.BabbageTransactionOutput{OutputAddress: scriptAddress(t, tc.script)},
+			})
+			results, err := EvaluateTx(buildSpendTx(t, budget, false, tc.script), testEvalParams(), mainnetSlotClock(t), resolve)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			result := results[0]
+			if result.Success != tc.success {
+				t.Errorf("expected success %v, got %+v", tc.success, result)
+			}
+			if result.Tag != lcommon.RedeemerTagSpend || result.Index != 0 || result.Language != "PlutusV3" {
+				t.Errorf("unexpected redeemer %+v", result)
+			}
+			if result.ScriptHash != tc.script.Hash().String() {
+				t.Errorf("expected script hash %s, got %s", tc.script.Hash().String(), result.ScriptHash)
+			}
+			if result.Budget != budget {
+				t.Errorf("expected declared budget %+v, got %+v", budget, result.Budget)
+			}
+			if result.ExUnits.Memory <= 0 || result.ExUnits.Steps <= 0 {
+				t.Errorf("expected consumed execution units, got %+v", result.ExUnits)
+			}
+			if result.WithinBudget != tc.success {
+				t.Errorf("expected within budget %v, got %+v", tc.success, result)
+			}
+			if !slices.Equal(result.Traces, []string{tc.trace}) {
+				t.Errorf("expected traces [%s], got %v", tc.trace, result.Traces)
+			}
+			if tc.success != (result.Error == "") {
+				t.Errorf("unexpected error message %q", result.Error)
+			}
+		})
+	}
+}
+
+func TestEvaluateTx_ReferenceScript(t *testing.T) {
+	s := compilePlutusV3(t, traceSucceedsScript)
+	resolve := fixtureResolver(map[string]ledger.TransactionOutput{
+		spentInput: babbage.BabbageTransactionOutput{OutputAddress: scriptAddress(t, s)},
+		refInput: babbage.BabbageTransactionOutput{
+			OutputAddress:  scriptAddress(t, s),
+			TxOutScriptRef: &lcommon.ScriptRef{Type: lcommon.ScriptRefTypePlutusV3, Script: s},
+		},
+	})
+	// No declared budget, but the script runs within the transaction limit
+	results, err := EvaluateTx(buildSpendTx(t, lcommon.ExUnits{}, true), testEvalParams(), mainnetSlotClock(t), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("expected reference script to succeed, got %+v", results)
+	}
+	if results[0].ExUnits.Memory <= 0 || results[0].ExUnits.Steps <= 0 {
+		t.Errorf("expected consumed execution units, got %+v", results[0].ExUnits)
+	}
+	if results[0].WithinBudget {
+		t.Errorf("expected the empty budget not to be enough, got %+v", results[0])
+	}
+}
+
+func TestEvaluateTx_OverBudget(t *testing.T) {
+	s := compilePlutusV3(t, traceSucceedsScript)
+	resolve := fixtureResolver(map[string]ledger.TransactionOutput{
+		spentInput: babbage.BabbageTransactionOutput{OutputAddress: scriptAddress(t, s)},
+	})
+	// A placeholder budget below what the script uses is reported, rather
+	// than failing the script
+	txBytes := buildSpendTx(t, lcommon.ExUnits{Memory: 100, Steps: 10_000}, false, s)
+	results, err := EvaluateTx(txBytes, testEvalParams(), mainnetSlotClock(t), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || !results[0].Success || results[0].WithinBudget {
+		t.Fatalf("expected script to succeed over its declared budget, got %+v", results)
+	}
+	if results[0].ExUnits.Memory <= 100 {
+		t.Errorf("expected consumed execution units over the budget, got %+v", results[0].ExUnits)
+	}
+
+	// The transaction limit is still enforced
+	pparams := testEvalParams()
+	pparams.MaxTxExecutionUnits = &lcommon.ExUnits{Memory: 100, Steps: 10_000}
+	results, err = EvaluateTx(txBytes, pparams, mainnetSlotClock(t), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || results[0].Success || results[0].Error == "" {
+		t.Fatalf("expected script to exceed the transaction limit, got %+v", results)
+	}
+}
+
+func TestEvaluateTx_MissingScript(t *testing.T) {
+	s := compilePlutusV3(t, traceSucceedsScript)
+	resolve := fixtureResolver(map[string]ledger.TransactionOutput{
+		spentInput: babbage.BabbageTransactionOutput{OutputAddress: scriptAddress(t, s)},
+	})
+	results, err := EvaluateTx(buildSpendTx(t, lcommon.ExUnits{}, false), testEvalParams(), mainnetSlotClock(t), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || results[0].Success || !strings.Contains(results[0].Error, "no witness or reference script") {
+		t.Fatalf("expected missing script failure, got %+v", results)
+	}
+}
+
+func TestEvaluateTx_UnresolvedInputs(t *testing.T) {
+	s := compilePlutusV3(t, traceSucceedsScript)
+	_, err := EvaluateTx(buildSpendTx(t, lcommon.ExUnits{}, true, s), testEvalParams(), mainnetSlotClock(t), fixtureResolver(nil))
+	var unresolved *UnresolvedInputsError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("expected UnresolvedInputsError, got %v", err)
+	}
+	if !slices.Equal(unresolved.Inputs, []string{spentInput, refInput}) {
+		t.Errorf("expected unresolved inputs %s and %s, got %v", spentInput, refInput, unresolved.Inputs)
+	}
+}
+
+func TestEvaluateTx_NoRedeemers(t *testing.T) {
+	results, err := EvaluateTx(buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{}), testEvalParams(), mainnetSlotClock(t), fixtureResolver(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %+v", results)
+	}
+}
+
+// datumOutput returns an output locked by s carrying datum inline, or only
+// its hash when inline is false.
+func datumOutput(t *testing.T, s lcommon.Script, datum []byte, inline bool) ledger.TransactionOutput {
+	t.Helper()
+	option := []any{0, lcommon.Blake2b256Hash(datum).Bytes()}
+	if inline {
+		option = []any{1, gocbor.Tag{Number: gocbor.CborTagCbor, Content: datum}}
+	}
+	output, err := babbage.NewBabbageTransactionOutputFromCbor(mustEncode(t, map[uint]any{
+		0: scriptAddress(t, s),
+		1: uint64(5_000_000),
+		2: option,
+	}))
+	if err != nil {
+		t.Fatalf("decode output: %s", err)
+	}
+	return output
+}
+
+func TestEvaluateTx_PlutusV2Datum(t *testing.T) {
+	s := lcommon.PlutusV2Script(compileScript(t, datumCheckScript))
+	datum := mustEncode(t, int64(7))
+	wrongDatum := mustEncode(t, int64(8))
+
+	testCases := []struct {
+		name          string
+		output        ledger.TransactionOutput
+		witnessDatums []any
+		success       bool
+		wantErr       string
+	}{
+		{name: "inline datum", output: datumOutput(t, s, datum, true), success: true},
+		{
+			name:          "witness datum",
+			output:        datumOutput(t, s, datum, false),
+			witnessDatums: []any{gocbor.RawMessage(datum)},
+			success:       true,
+		},
+		{name: "wrong datum", output: datumOutput(t, s, wrongDatum, true)},
+		{
+			name:    "missing datum",
+			output:  datumOutput(t, s, datum, false),
+			wantErr: "missing datum for spent input " + spentInput,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			witnesses := map[uint]any{
+				5: [][]any{{uint8(lcommon.RedeemerTagSpend), uint32(0), int64(42), []int64{0, 0}}},
+				6: [][]byte{s},
+			}
+			if tc.witnessDatums != nil {
+				witnesses[4] = tc.witnessDatums
+			}
+			txBytes := buildConwayTx(t, buildMinimalConwayBody(), witnesses)
+			resolve := fixtureResolver(map[string]ledger.TransactionOutput{spentInput: tc.output})
+			results, err := EvaluateTx(txBytes, testEvalParams(), mainnetSlotClock(t), resolve)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			result := results[0]
+			if result.Language != "PlutusV2" || result.ScriptHash != s.Hash().String() {
+				t.Errorf("unexpected redeemer %+v", result)
+			}
+			if result.Success != tc.success {
+				t.Errorf("expected success %v, got %+v", tc.success, result)
+			}
+			if tc.wantErr != "" && result.Error != tc.wantErr {
+				t.Errorf("expected error %q, got %q", tc.wantErr, result.Error)
+			}
+		})
+	}
+}
+
+func TestEvaluateTx_ProtocolMajor(t *testing.T) {
+	s := lcommon.PlutusV2Script(compileScript(t, datumCheckScript))
+	tx, err := decodeTx(buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resolve := fixtureResolver(map[string]ledger.TransactionOutput{
+		spentInput: datumOutput(t, s, mustEncode(t, int64(7)), true),
+	})
+	e, err := newScriptEval(tx, testEvalParams(), mainnetSlotClock(t), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The V1 and V2 script contexts encode the mint field differently
+	// depending on the protocol version
+	v1, err := e.buildTxInfo(lang.LanguageVersionV1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := v1.(script.TxInfoV1).ProtocolMajor; got != 10 {
+		t.Errorf("expected V1 protocol major 10, got %d", got)
+	}
+	v2, err := e.buildTxInfo(lang.LanguageVersionV2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := v2.(script.TxInfoV2).ProtocolMajor; got != 10 {
+		t.Errorf("expected V2 protocol major 10, got %d", got)
+	}
+}
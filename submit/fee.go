@@ -0,0 +1,228 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+const (
+	// Conway prices reference scripts in tiers of refScriptCostStride bytes,
+	// with each tier costing refScriptCostMultiplier times the previous one.
+	refScriptCostStride = 25600
+
+	// vkeyWitnessSize is the encoded size of a single vkey witness: a 2
+	// element array of a 32 byte key and a 64 byte signature.
+	vkeyWitnessSize = 1 + (2 + 32) + (2 + 64)
+
+	// vkeyWitnessSetTagSize allows for the 258 set tag that Conway
+	// transactions may use for the vkey witness list.
+	vkeyWitnessSetTagSize = 3
+)
+
+var refScriptCostMultiplier = big.NewRat(6, 5)
+
+// FeeEstimate is the minimum fee for a transaction, broken down by
+// component, compared to the fee it declares.
+type FeeEstimate struct {
+	DeclaredFee uint64 `json:"declaredFee"`
+	MinFee      uint64 `json:"minFee"`
+	// Shortfall is how much the declared fee is below the minimum fee, and
+	// Surplus is how much it is above it.
+	Shortfall uint64 `json:"shortfall"`
+	Surplus   uint64 `json:"surplus"`
+
+	// TxSize is the fee-relevant size in bytes once the expected vkey
+	// witnesses are added.
+	TxSize        uint64 `json:"txSize"`
+	VkeyWitnesses int    `json:"vkeyWitnesses"`
+	LinearFee     uint64 `json:"linearFee"`
+
+	RefScriptSize uint64 `json:"refScriptSize"`
+	RefScriptFee  uint64 `json:"refScriptFee"`
+	// UnresolvedInputs lists spent and reference inputs that were not found
+	// in the UTxO set, so their reference scripts are not included.
+	UnresolvedInputs []string `json:"unresolvedInputs,omitempty"`
+
+	ExUnits    lcommon.ExUnits `json:"exUnits"`
+	ExUnitsFee uint64          `json:"exUnitsFee"`
+}
+
+// UTxOResolver looks up transaction inputs in the UTxO set, returning the
+// outputs keyed by "<tx hash>#<index>".
+type UTxOResolver func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error)
+
+// EstimateFee computes the minimum fee for an unsigned or partially signed
+// transaction once it carries vkeyWitnesses vkey witnesses. The minimum fee
+// is the linear size fee, plus the execution unit fee of its redeemers, plus
+// the tiered reference script fee from Conway onwards. resolve is used to
+// find the reference scripts of spent and reference inputs, and may be nil
+// to skip them.
+func EstimateFee(
+	txRawBytes []byte,
+	vkeyWitnesses int,
+	pparams *ProtocolParams,
+	resolve UTxOResolver,
+) (*FeeEstimate, error) {
+	if vkeyWitnesses < 0 {
+		return nil, errors.New("vkey witness count must not be negative")
+	}
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	ret := &FeeEstimate{}
+	if fee := tx.Fee(); fee != nil {
+		ret.DeclaredFee = fee.Uint64()
+	}
+
+	// Linear fee over the size the transaction will have once signed
+	size, err := lcommon.TxSizeForFee(tx)
+	if err != nil {
+		return nil, err
+	}
+	var existing int
+	if w := tx.Witnesses(); w != nil {
+		existing = len(w.Vkey())
+	}
+	ret.VkeyWitnesses = max(vkeyWitnesses, existing)
+	ret.TxSize = uint64(size) + vkeyWitnessesExtraSize(existing, ret.VkeyWitnesses) // #nosec G115
+	linearFee := new(big.Int).SetUint64(pparams.TxFeePerByte)
+	linearFee.Mul(linearFee, new(big.Int).SetUint64(ret.TxSize))
+	linearFee.Add(linearFee, new(big.Int).SetUint64(pparams.TxFeeFixed))
+	minFee := new(big.Int).Set(linearFee)
+
+	// Execution unit fee over the total ex-units of all redeemers
+	exUnitsFee := new(big.Int)
+	if w := tx.Witnesses(); w != nil && w.Redeemers() != nil {
+		for _, redeemer := range w.Redeemers().Iter() {
+			if redeemer.ExUnits.Memory < 0 || redeemer.ExUnits.Steps < 0 {
+				return nil, errors.New("redeemer has negative execution units")
+			}
+			if redeemer.ExUnits.Memory > math.MaxInt64-ret.ExUnits.Memory ||
+				redeemer.ExUnits.Steps > math.MaxInt64-ret.ExUnits.Steps {
+				return nil, errors.New("total execution units out of range")
+			}
+			ret.ExUnits.Memory += redeemer.ExUnits.Memory
+			ret.ExUnits.Steps += redeemer.ExUnits.Steps
+		}
+	}
+	if prices := pparams.ExecutionUnitPrices; prices != nil && prices.PriceMemory != nil && prices.PriceSteps != nil {
+		fee := new(big.Rat).Mul(prices.PriceMemory.Rat(), new(big.Rat).SetInt64(ret.ExUnits.Memory))
+		fee.Add(fee, new(big.Rat).Mul(prices.PriceSteps.Rat(), new(big.Rat).SetInt64(ret.ExUnits.Steps)))
+		exUnitsFee = ratCeil(fee)
+		minFee.Add(minFee, exUnitsFee)
+	}
+
+	// Reference script fee over the scripts in spent and reference inputs
+	refScriptFee := new(big.Int)
+	if pparams.MinFeeRefScriptCostPerByte != nil && resolve != nil {
+		// Inputs are counted once per list they appear in, as the ledger does
+		inputs := slices.Concat(tx.Inputs(), tx.ReferenceInputs())
+		if len(inputs) > 0 {
+			utxos, err := resolve(inputs)
+			if err != nil {
+				return nil, err
+			}
+			for _, input := range inputs {
+				output, ok := utxos[input.String()]
+				if !ok {
+					ret.UnresolvedInputs = append(ret.UnresolvedInputs, input.String())
+					continue
+				}
+				if script := output.ScriptRef(); script != nil {
+					ret.RefScriptSize += uint64(len(script.RawScriptBytes()))
+				}
+			}
+		}
+		refScriptFee = tierRefScriptFee(pparams.MinFeeRefScriptCostPerByte.Rat(), ret.RefScriptSize)
+		minFee.Add(minFee, refScriptFee)
+	}
+
+	if !minFee.IsUint64() {
+		return nil, fmt.Errorf("minimum fee out of range: %s", minFee.String())
+	}
+	ret.LinearFee = linearFee.Uint64()
+	ret.ExUnitsFee = exUnitsFee.Uint64()
+	ret.RefScriptFee = refScriptFee.Uint64()
+	ret.MinFee = minFee.Uint64()
+	if ret.DeclaredFee < ret.MinFee {
+		ret.Shortfall = ret.MinFee - ret.DeclaredFee
+	} else {
+		ret.Surplus = ret.DeclaredFee - ret.MinFee
+	}
+	return ret, nil
+}
+
+// vkeyWitnessesExtraSize estimates how many bytes adding vkey witnesses
+// grows a transaction by, going from existing to total witnesses. It errs
+// on the high side where the encoding is not known in advance.
+func vkeyWitnessesExtraSize(existing, total int) uint64 {
+	if total <= existing {
+		return 0
+	}
+	extra := uint64(total-existing) * vkeyWitnessSize // #nosec G115
+	if existing == 0 {
+		// New witness set map key, set tag and list header
+		return extra + 1 + vkeyWitnessSetTagSize + cborHeaderSize(total)
+	}
+	return extra + cborHeaderSize(total) - cborHeaderSize(existing)
+}
+
+// cborHeaderSize returns the encoded size of a CBOR array header for n items.
+func cborHeaderSize(n int) uint64 {
+	switch {
+	case n < 24:
+		return 1
+	case n < 1<<8:
+		return 2
+	case n < 1<<16:
+		return 3
+	default:
+		return 5
+	}
+}
+
+// tierRefScriptFee computes the Conway reference script fee for size bytes
+// of scripts. Each full stride of bytes costs refScriptCostMultiplier times
+// more per byte than the one before it, and the total is rounded down.
+func tierRefScriptFee(costPerByte *big.Rat, size uint64) *big.Int {
+	acc := new(big.Rat)
+	price := new(big.Rat).Set(costPerByte)
+	stride := new(big.Rat).SetInt64(refScriptCostStride)
+	for size >= refScriptCostStride {
+		acc.Add(acc, new(big.Rat).Mul(stride, price))
+		price.Mul(price, refScriptCostMultiplier)
+		size -= refScriptCostStride
+	}
+	acc.Add(acc, new(big.Rat).Mul(new(big.Rat).SetInt(new(big.Int).SetUint64(size)), price))
+	return new(big.Int).Quo(acc.Num(), acc.Denom())
+}
+
+// ratCeil rounds a non-negative rational up to an integer.
+func ratCeil(r *big.Rat) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func testFeeParams() *ProtocolParams {
+	return &ProtocolParams{
+		TxFeePerByte: 44,
+		TxFeeFixed:   155381,
+		ExecutionUnitPrices: &ExecutionUnitPrices{
+			PriceMemory: (*Ratio)(big.NewRat(577, 10000)),
+			PriceSteps:  (*Ratio)(big.NewRat(721, 10000000)),
+		},
+		MinFeeRefScriptCostPerByte: (*Ratio)(big.NewRat(15, 1)),
+	}
+}
+
+func TestEstimateFee_Linear(t *testing.T) {
+	t.Parallel()
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	// The trailing IsValid flag is not counted towards the fee size
+	baseSize := uint64(len(txBytes) - 1)
+
+	est, err := EstimateFee(txBytes, 0, testFeeParams(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if est.TxSize != baseSize {
+		t.Errorf("TxSize: want %d, got %d", baseSize, est.TxSize)
+	}
+	wantMin := 44*baseSize + 155381
+	if est.MinFee != wantMin || est.LinearFee != wantMin {
+		t.Errorf("MinFee: want %d, got %d (linear %d)", wantMin, est.MinFee, est.LinearFee)
+	}
+	if est.DeclaredFee != 100_000 {
+		t.Errorf("DeclaredFee: want 100000, got %d", est.DeclaredFee)
+	}
+	if est.Shortfall != wantMin-100_000 || est.Surplus != 0 {
+		t.Errorf("unexpected shortfall %d / surplus %d", est.Shortfall, est.Surplus)
+	}
+}
+
+func TestEstimateFee_VkeyWitnesses(t *testing.T) {
+	t.Parallel()
+	unsigned := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	signed := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{
+		0: []any{[]any{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 64)}},
+	})
+
+	unsignedEst, err := EstimateFee(unsigned, 1, testFeeParams(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	signedEst, err := EstimateFee(signed, 1, testFeeParams(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The estimate for the unsigned tx must cover the signed tx, allowing
+	// for the set tag that may not be used.
+	if unsignedEst.TxSize < signedEst.TxSize || unsignedEst.TxSize > signedEst.TxSize+vkeyWitnessSetTagSize {
+		t.Errorf("unsigned estimate %d does not match signed size %d", unsignedEst.TxSize, signedEst.TxSize)
+	}
+	if signedEst.TxSize != uint64(len(signed)-1) {
+		t.Errorf("expected no extra size for existing witness, got %d for %d byte tx", signedEst.TxSize, len(signed))
+	}
+	if signedEst.VkeyWitnesses != 1 {
+		t.Errorf("VkeyWitnesses: want 1, got %d", signedEst.VkeyWitnesses)
+	}
+
+	if _, err := EstimateFee(unsigned, -1, testFeeParams(), nil); err == nil {
+		t.Error("expected error for negative witness count")
+	}
+}
+
+func TestEstimateFee_ExUnits(t *testing.T) {
+	t.Parallel()
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{
+		5: []any{
+			[]any{uint(0), uint(0), uint64(0), []any{uint64(1_000_000), uint64(400_000_000)}},
+			[]any{uint(1), uint(0), uint64(0), []any{uint64(500_000), uint64(100_000_000)}},
+		},
+	})
+	est, err := EstimateFee(txBytes, 0, testFeeParams(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if est.ExUnits.Memory != 1_500_000 || est.ExUnits.Steps != 500_000_000 {
+		t.Errorf("unexpected total ex-units %+v", est.ExUnits)
+	}
+	// 0.0577 * 1500000 + 0.0000721 * 500000000 = 86550 + 36050
+	if est.ExUnitsFee != 122600 {
+		t.Errorf("ExUnitsFee: want 122600, got %d", est.ExUnitsFee)
+	}
+	if est.MinFee != est.LinearFee+est.ExUnitsFee {
+		t.Errorf("MinFee %d is not linear %d + ex-units %d", est.MinFee, est.LinearFee, est.ExUnitsFee)
+	}
+}
+
+func TestEstimateFee_RefScripts(t *testing.T) {
+	t.Parallel()
+	body := buildMinimalConwayBody()
+	body[18] = [][]any{{bytes.Repeat([]byte{0xaa}, 32), uint32(1)}}
+	txBytes := buildConwayTx(t, body, map[uint]any{})
+
+	zeroInput := strings.Repeat("00", 32) + "#0"
+	refInput := strings.Repeat("aa", 32) + "#1"
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		if len(inputs) != 2 {
+			t.Errorf("expected spent and reference input to be resolved, got %d", len(inputs))
+		}
+		return map[string]ledger.TransactionOutput{
+			refInput: babbage.BabbageTransactionOutput{
+				TxOutScriptRef: &lcommon.ScriptRef{
+					Type:   3,
+					Script: lcommon.PlutusV3Script(make([]byte, 1000)),
+				},
+			},
+		}, nil
+	}
+	est, err := EstimateFee(txBytes, 0, testFeeParams(), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if est.RefScriptSize != 1000 || est.RefScriptFee != 15000 {
+		t.Errorf("unexpected ref script size %d / fee %d", est.RefScriptSize, est.RefScriptFee)
+	}
+	if len(est.UnresolvedInputs) != 1 || est.UnresolvedInputs[0] != zeroInput {
+		t.Errorf("expected spent input to be unresolved, got %v", est.UnresolvedInputs)
+	}
+
+	_, err = EstimateFee(txBytes, 0, testFeeParams(), func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if err == nil {
+		t.Error("expected resolver error to be returned")
+	}
+}
+
+func TestTierRefScriptFee(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		size uint64
+		want int64
+	}{
+		{size: 0, want: 0},
+		{size: 1000, want: 15000},
+		{size: 25600, want: 384000},
+		// First byte of the second tier costs 15 * 1.2
+		{size: 25601, want: 384018},
+		// 384000 + 25600 * 18 + 100 * 21.6
+		{size: 51300, want: 846960},
+	}
+	for _, tt := range tests {
+		if got := tierRefScriptFee(big.NewRat(15, 1), tt.size); got.Int64() != tt.want {
+			t.Errorf("size %d: want %d, got %s", tt.size, tt.want, got)
+		}
+	}
+}
+
+func TestEstimateFee_InvalidCBOR(t *testing.T) {
+	t.Parallel()
+	if _, err := EstimateFee([]byte("not-valid-cbor"), 1, testFeeParams(), nil); err == nil {
+		t.Error("expected error for invalid CBOR, got nil")
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/protocol/localtxmonitor"
+)
+
+// MempoolSizes holds the occupancy of a node mempool snapshot as reported by
+// LocalTxMonitor GetSizes.
+type MempoolSizes struct {
+	CapacityBytes uint32 `json:"capacityBytes"`
+	SizeBytes     uint32 `json:"sizeBytes"`
+	TxCount       uint32 `json:"txCount"`
+}
+
+// MempoolTx is a single transaction taken from a node mempool snapshot.
+type MempoolTx struct {
+	// Hash is empty if the transaction could not be decoded.
+	Hash string
+	Cbor []byte
+}
+
+// dialMempool dials the node with LocalTxMonitor timeouts derived from
+// cfg.Timeout. Callers are responsible for closing the returned connection.
+func dialMempool(cfg *Config) (*ouroboros.Connection, error) {
+	if cfg.Timeout > math.MaxInt64 {
+		return nil, errors.New("given timeout too large")
+	}
+	if err := cfg.populateNetworkMagic(); err != nil {
+		return nil, fmt.Errorf("failed to populate networkMagic: %w", err)
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second // #nosec G115
+	return DialNode(
+		cfg.NetworkMagic,
+		cfg.NodeAddress,
+		cfg.NodePort,
+		cfg.SocketPath,
+		ouroboros.WithLocalTxMonitorConfig(
+			localtxmonitor.NewConfig(
+				localtxmonitor.WithAcquireTimeout(timeout),
+				localtxmonitor.WithQueryTimeout(timeout),
+			),
+		),
+	)
+}
+
+// GetMempoolSizes acquires a mempool snapshot and returns its capacity, size
+// and transaction count. The slot the snapshot was acquired at is not
+// returned, as gouroboros keeps it private to the LocalTxMonitor client.
+func GetMempoolSizes(cfg *Config) (*MempoolSizes, error) {
+	oConn, err := dialMempool(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oConn.Close()
+
+	capacity, size, count, err := oConn.LocalTxMonitor().Client.GetSizes()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting mempool sizes: %w", err)
+	}
+	return &MempoolSizes{
+		CapacityBytes: capacity,
+		SizeBytes:     size,
+		TxCount:       count,
+	}, nil
+}
+
+// GetMempoolStatus acquires a single mempool snapshot and returns its sizes
+// along with whether each of txHashes is present in it.
+func GetMempoolStatus(cfg *Config, txHashes [][]byte) (*MempoolSizes, []bool, error) {
+	oConn, err := dialMempool(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer oConn.Close()
+
+	client := oConn.LocalTxMonitor().Client
+	capacity, size, count, err := client.GetSizes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failure getting mempool sizes: %w", err)
+	}
+	present := make([]bool, len(txHashes))
+	for i, txHash := range txHashes {
+		present[i], err = client.HasTx(txHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failure getting transaction: %w", err)
+		}
+	}
+	return &MempoolSizes{
+		CapacityBytes: capacity,
+		SizeBytes:     size,
+		TxCount:       count,
+	}, present, nil
+}
+
+// ListMempoolTxs acquires a mempool snapshot and returns up to limit
+// transactions after skipping the first offset. The returned bool reports
+// whether the snapshot holds further transactions past the returned page.
+// Each call acquires a new snapshot, so successive pages can skip or repeat
+// transactions if the mempool changed in between.
+func ListMempoolTxs(cfg *Config, offset, limit int) ([]MempoolTx, bool, error) {
+	if offset < 0 || limit < 0 {
+		return nil, false, errors.New("offset and limit must not be negative")
+	}
+	oConn, err := dialMempool(cfg)
+	if err != nil {
+		return nil, false, err
+	}
+	defer oConn.Close()
+
+	return listMempoolTxs(oConn.LocalTxMonitor().Client.NextTx, offset, limit)
+}
+
+// listMempoolTxs walks a mempool snapshot with next and returns the page of
+// transactions after offset. A transaction that can't be decoded is returned
+// with an empty hash rather than failing the whole page.
+func listMempoolTxs(next func() ([]byte, error), offset, limit int) ([]MempoolTx, bool, error) {
+	txs := make([]MempoolTx, 0, limit)
+	for idx := 0; ; idx++ {
+		txBytes, err := next()
+		if err != nil {
+			return nil, false, fmt.Errorf("failure getting next mempool transaction: %w", err)
+		}
+		if txBytes == nil {
+			return txs, false, nil
+		}
+		if idx < offset {
+			continue
+		}
+		if len(txs) == limit {
+			return txs, true, nil
+		}
+		// The hash is left empty for a transaction that can't be decoded
+		txHash, _ := mempoolTxHash(txBytes)
+		txs = append(txs, MempoolTx{Hash: txHash, Cbor: txBytes})
+	}
+}
+
+// GetMempoolTx acquires a mempool snapshot and returns the raw CBOR of the
+// transaction with the given hash. It returns nil without an error when the
+// transaction is not in the mempool.
+func GetMempoolTx(cfg *Config, txHash []byte) ([]byte, error) {
+	oConn, err := dialMempool(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oConn.Close()
+
+	client := oConn.LocalTxMonitor().Client
+	// HasTx is cheap on the node side, so use it to avoid walking the whole
+	// snapshot for a transaction that isn't there.
+	hasTx, err := client.HasTx(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failure getting transaction: %w", err)
+	}
+	if !hasTx {
+		return nil, nil
+	}
+	return findMempoolTx(client.NextTx, fmt.Sprintf("%x", txHash))
+}
+
+// findMempoolTx walks a mempool snapshot with next and returns the
+// transaction with the given hash, skipping transactions that can't be
+// decoded.
+func findMempoolTx(next func() ([]byte, error), wantHash string) ([]byte, error) {
+	for {
+		txBytes, err := next()
+		if err != nil {
+			return nil, fmt.Errorf("failure getting next mempool transaction: %w", err)
+		}
+		if txBytes == nil {
+			return nil, nil
+		}
+		gotHash, err := mempoolTxHash(txBytes)
+		if err == nil && gotHash == wantHash {
+			return txBytes, nil
+		}
+	}
+}
+
+func mempoolTxHash(txBytes []byte) (string, error) {
+	txType, err := ledger.DetermineTransactionType(txBytes)
+	if err != nil {
+		return "", fmt.Errorf("could not parse mempool transaction to determine type: %w", err)
+	}
+	tx, err := ledger.NewTransactionFromCbor(txType, txBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse mempool transaction CBOR: %w", err)
+	}
+	return tx.Hash().String(), nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// snapshot returns a NextTx-like function walking txs, returning nil after
+// the last one.
+func snapshot(txs ...[]byte) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		if len(txs) == 0 {
+			return nil, nil
+		}
+		next := txs[0]
+		txs = txs[1:]
+		return next, nil
+	}
+}
+
+func TestListMempoolTxs_Undecodable(t *testing.T) {
+	t.Parallel()
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	txHash, err := mempoolTxHash(txBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	bad := []byte("not-valid-cbor")
+
+	txs, hasMore, err := listMempoolTxs(snapshot(bad, txBytes), 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hasMore || len(txs) != 2 {
+		t.Fatalf("expected the whole snapshot, got %d txs (hasMore %t)", len(txs), hasMore)
+	}
+	if txs[0].Hash != "" || !bytes.Equal(txs[0].Cbor, bad) {
+		t.Errorf("expected undecodable tx with an empty hash, got %q", txs[0].Hash)
+	}
+	if txs[1].Hash != txHash {
+		t.Errorf("expected hash %s, got %q", txHash, txs[1].Hash)
+	}
+
+	txs, hasMore, err = listMempoolTxs(snapshot(bad, txBytes, txBytes), 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !hasMore || len(txs) != 1 || txs[0].Hash != txHash {
+		t.Errorf("unexpected page %+v (hasMore %t)", txs, hasMore)
+	}
+}
+
+func TestFindMempoolTx(t *testing.T) {
+	t.Parallel()
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	txHash, err := mempoolTxHash(txBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := findMempoolTx(snapshot([]byte("not-valid-cbor"), txBytes), txHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(got, txBytes) {
+		t.Error("expected the tx past the undecodable one to be found")
+	}
+	got, err = findMempoolTx(snapshot(txBytes), "00")
+	if err != nil || got != nil {
+		t.Errorf("expected no tx and no error, got %x, %v", got, err)
+	}
+	failing := func() ([]byte, error) { return nil, errors.New("connection reset") }
+	if _, err := findMempoolTx(failing, txHash); err == nil {
+		t.Error("expected snapshot error to be returned")
+	}
+}
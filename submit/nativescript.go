@@ -0,0 +1,159 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// NativeScriptResult is the result of evaluating a native script of a
+// transaction witness set.
+type NativeScriptResult struct {
+	Hash string `json:"hash"`
+	// Type is the top-level clause of the script: "sig", "all", "any",
+	// "n-of-k", "before" or "after".
+	Type string `json:"type"`
+	Pass bool   `json:"pass"`
+	// MissingSigners are the key hashes of unsatisfied sig clauses, for a
+	// failed script.
+	MissingSigners []string `json:"missingSigners,omitempty"`
+	// FailedTimelocks are the unsatisfied before and after clauses, such as
+	// "before 12345678", for a failed script.
+	FailedTimelocks []string `json:"failedTimelocks,omitempty"`
+}
+
+func (r NativeScriptResult) String() string {
+	if r.Pass {
+		return fmt.Sprintf("native script %s passed", r.Hash)
+	}
+	var reasons []string
+	if len(r.MissingSigners) > 0 {
+		reasons = append(reasons, "missing signatures from "+strings.Join(r.MissingSigners, ", "))
+	}
+	if len(r.FailedTimelocks) > 0 {
+		reasons = append(reasons, "validity interval not within "+strings.Join(r.FailedTimelocks, ", "))
+	}
+	return fmt.Sprintf("native script %s failed: %s", r.Hash, strings.Join(reasons, "; "))
+}
+
+// nativeScriptEval holds the transaction state native scripts are evaluated
+// against.
+type nativeScriptEval struct {
+	validityStart uint64
+	validityEnd   uint64
+	keyHashes     map[lcommon.Blake2b224]bool
+}
+
+func (e *nativeScriptEval) eval(script *lcommon.NativeScript) bool {
+	return script.Evaluate(0, e.validityStart, e.validityEnd, e.keyHashes)
+}
+
+// EvaluateNativeScripts evaluates each native script in the witness set of a
+// transaction against the key hashes of its vkey witnesses and its validity
+// interval, as the ledger does. Signatures are not verified here, see
+// VerifyWitnesses.
+func EvaluateNativeScripts(txRawBytes []byte) ([]NativeScriptResult, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	w := tx.Witnesses()
+	if w == nil {
+		return nil, nil
+	}
+	e := &nativeScriptEval{
+		validityStart: tx.ValidityIntervalStart(),
+		validityEnd:   tx.TTL(),
+		keyHashes:     make(map[lcommon.Blake2b224]bool),
+	}
+	if e.validityEnd == 0 {
+		e.validityEnd = math.MaxUint64
+	}
+	for _, vw := range w.Vkey() {
+		e.keyHashes[lcommon.Blake2b224Hash(vw.Vkey)] = true
+	}
+	scripts := w.NativeScripts()
+	ret := make([]NativeScriptResult, 0, len(scripts))
+	for i := range scripts {
+		script := &scripts[i]
+		result := NativeScriptResult{
+			Hash: script.Hash().String(),
+			Type: nativeScriptType(script),
+			Pass: e.eval(script),
+		}
+		if !result.Pass {
+			e.explain(script, &result)
+			slices.Sort(result.MissingSigners)
+			result.MissingSigners = slices.Compact(result.MissingSigners)
+			result.FailedTimelocks = slices.Compact(result.FailedTimelocks)
+		}
+		ret = append(ret, result)
+	}
+	return ret, nil
+}
+
+// explain adds the unsatisfied sig and timelock clauses of a failed script to
+// result. Clauses of an "any" or "n-of-k" script are all reported, as any of
+// them being satisfied may fix it.
+func (e *nativeScriptEval) explain(script *lcommon.NativeScript, result *NativeScriptResult) {
+	if e.eval(script) {
+		return
+	}
+	var children []lcommon.NativeScript
+	switch s := script.Item().(type) {
+	case *lcommon.NativeScriptPubkey:
+		result.MissingSigners = append(result.MissingSigners, hex.EncodeToString(s.Hash))
+	case *lcommon.NativeScriptInvalidBefore:
+		result.FailedTimelocks = append(result.FailedTimelocks, fmt.Sprintf("after %d", s.Slot))
+	case *lcommon.NativeScriptInvalidHereafter:
+		result.FailedTimelocks = append(result.FailedTimelocks, fmt.Sprintf("before %d", s.Slot))
+	case *lcommon.NativeScriptAll:
+		children = s.Scripts
+	case *lcommon.NativeScriptAny:
+		children = s.Scripts
+	case *lcommon.NativeScriptNofK:
+		children = s.Scripts
+	}
+	for i := range children {
+		e.explain(&children[i], result)
+	}
+}
+
+// nativeScriptType returns the name of the top-level clause of a native
+// script, using the cardano-cli JSON names for the time locks.
+func nativeScriptType(script *lcommon.NativeScript) string {
+	switch script.Item().(type) {
+	case *lcommon.NativeScriptPubkey:
+		return "sig"
+	case *lcommon.NativeScriptAll:
+		return "all"
+	case *lcommon.NativeScriptAny:
+		return "any"
+	case *lcommon.NativeScriptNofK:
+		return "n-of-k"
+	case *lcommon.NativeScriptInvalidBefore:
+		return "after"
+	case *lcommon.NativeScriptInvalidHereafter:
+		return "before"
+	default:
+		return "unknown"
+	}
+}
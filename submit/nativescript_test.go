@@ -0,0 +1,104 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func TestEvaluateNativeScripts(t *testing.T) {
+	t.Parallel()
+	// Signatures are not verified, so any 32-byte key will do
+	vkey := bytes.Repeat([]byte{0x21}, 32)
+	signer := lcommon.Blake2b224Hash(vkey).Bytes()
+	other := bytes.Repeat([]byte{0x31}, 28)
+	third := bytes.Repeat([]byte{0x41}, 28)
+
+	sig := func(keyHash []byte) []any { return []any{uint(0), keyHash} }
+	timelockPolicy := []any{uint(1), []any{sig(signer), []any{uint(5), uint64(1000)}}}
+	multisig := []any{uint(3), uint(2), []any{sig(signer), sig(other), sig(third)}}
+	after := []any{uint(4), uint64(500)}
+
+	tests := []struct {
+		name          string
+		script        []any
+		ttl           uint64
+		validityStart uint64
+		signed        bool
+		wantType      string
+		wantPass      bool
+		wantMissing   []string
+		wantTimelocks []string
+	}{
+		{"timelock policy", timelockPolicy, 900, 0, true, "all", true, nil, nil},
+		{"timelock policy unsigned", timelockPolicy, 900, 0, false, "all", false,
+			[]string{lcommon.Blake2b224(signer).String()}, nil},
+		{"timelock policy expired", timelockPolicy, 2000, 0, true, "all", false, nil, []string{"before 1000"}},
+		{"timelock policy without ttl", timelockPolicy, 0, 0, true, "all", false, nil, []string{"before 1000"}},
+		{"2 of 3 with one signature", multisig, 0, 0, true, "n-of-k", false,
+			[]string{strings.Repeat("31", 28), strings.Repeat("41", 28)}, nil},
+		{"after", after, 0, 600, false, "after", true, nil, nil},
+		{"after without validity start", after, 0, 0, false, "after", false, nil, []string{"after 500"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := buildMinimalConwayBody()
+			if tt.ttl != 0 {
+				body[3] = tt.ttl
+			}
+			if tt.validityStart != 0 {
+				body[8] = tt.validityStart
+			}
+			witnesses := map[uint]any{1: []any{tt.script}}
+			if tt.signed {
+				witnesses[0] = [][]any{{vkey, make([]byte, 64)}}
+			}
+			results, err := EvaluateNativeScripts(buildConwayTx(t, body, witnesses))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			result := results[0]
+			if result.Type != tt.wantType || result.Pass != tt.wantPass {
+				t.Errorf("want %s pass=%t, got %s pass=%t", tt.wantType, tt.wantPass, result.Type, result.Pass)
+			}
+			if !slices.Equal(result.MissingSigners, tt.wantMissing) {
+				t.Errorf("want missing signers %v, got %v", tt.wantMissing, result.MissingSigners)
+			}
+			if !slices.Equal(result.FailedTimelocks, tt.wantTimelocks) {
+				t.Errorf("want failed timelocks %v, got %v", tt.wantTimelocks, result.FailedTimelocks)
+			}
+		})
+	}
+}
+
+func TestEvaluateNativeScripts_NoScripts(t *testing.T) {
+	t.Parallel()
+	results, err := EvaluateNativeScripts(buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %+v", results)
+	}
+}
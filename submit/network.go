@@ -0,0 +1,134 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// txBodyNetworkIdKey is the transaction body map key of the optional
+// network_id field.
+const txBodyNetworkIdKey = 15
+
+// NetworkIdForMagic returns the address network ID of the network with the
+// given magic. Unknown networks are assumed to be testnets.
+func NetworkIdForMagic(networkMagic uint32) uint {
+	if network, ok := ouroboros.NetworkByNetworkMagic(networkMagic); ok {
+		return uint(network.Id)
+	}
+	return lcommon.AddressNetworkTestnet
+}
+
+// NetworkMismatch is an address or network_id field of a transaction that is
+// for a different network.
+type NetworkMismatch struct {
+	// Field is "output", "collateral_return", "withdrawal" or "network_id".
+	Field string `json:"field"`
+	// Index is the position of the output, for outputs.
+	Index     int    `json:"index,omitempty"`
+	Address   string `json:"address,omitempty"`
+	NetworkId uint   `json:"networkId"`
+}
+
+func (m NetworkMismatch) String() string {
+	switch m.Field {
+	case "output":
+		return fmt.Sprintf("output %d address %s has network ID %d", m.Index, m.Address, m.NetworkId)
+	case "network_id":
+		return fmt.Sprintf("transaction body network_id is %d", m.NetworkId)
+	default:
+		return fmt.Sprintf("%s address %s has network ID %d", m.Field, m.Address, m.NetworkId)
+	}
+}
+
+// FindNetworkMismatches checks the network ID of the output addresses, the
+// collateral return address, the withdrawal reward addresses and the body
+// network_id field of a transaction against networkId, and returns those that
+// differ.
+func FindNetworkMismatches(txRawBytes []byte, networkId uint) ([]NetworkMismatch, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	var ret []NetworkMismatch
+	for i, output := range tx.Outputs() {
+		addr := output.Address()
+		if addr.NetworkId() != networkId {
+			ret = append(ret, NetworkMismatch{
+				Field:     "output",
+				Index:     i,
+				Address:   addr.String(),
+				NetworkId: addr.NetworkId(),
+			})
+		}
+	}
+	if output := tx.CollateralReturn(); output != nil {
+		if addr := output.Address(); addr.NetworkId() != networkId {
+			ret = append(ret, NetworkMismatch{
+				Field:     "collateral_return",
+				Address:   addr.String(),
+				NetworkId: addr.NetworkId(),
+			})
+		}
+	}
+	var withdrawals []NetworkMismatch
+	for addr := range tx.Withdrawals() {
+		if addr != nil && addr.NetworkId() != networkId {
+			withdrawals = append(withdrawals, NetworkMismatch{
+				Field:     "withdrawal",
+				Address:   addr.String(),
+				NetworkId: addr.NetworkId(),
+			})
+		}
+	}
+	// Withdrawals are a map, so sort them for a stable result
+	slices.SortFunc(withdrawals, func(a, b NetworkMismatch) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+	ret = append(ret, withdrawals...)
+	if bodyNetworkId, ok := txBodyNetworkId(txRawBytes); ok && bodyNetworkId != networkId {
+		ret = append(ret, NetworkMismatch{Field: "network_id", NetworkId: bodyNetworkId})
+	}
+	return ret, nil
+}
+
+// txBodyNetworkId returns the network_id field of a transaction body, if it
+// is set. The field is read from the CBOR directly, as not every era's
+// decoded body tells an unset field apart from a network ID of 0.
+func txBodyNetworkId(txRawBytes []byte) (uint, bool) {
+	var parts []cbor.RawMessage
+	if _, err := cbor.Decode(txRawBytes, &parts); err != nil || len(parts) == 0 {
+		return 0, false
+	}
+	var body map[uint]cbor.RawMessage
+	if _, err := cbor.Decode(parts[0], &body); err != nil {
+		return 0, false
+	}
+	raw, ok := body[txBodyNetworkIdKey]
+	if !ok {
+		return 0, false
+	}
+	var networkId uint
+	if _, err := cbor.Decode(raw, &networkId); err != nil {
+		return 0, false
+	}
+	return networkId, true
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// enterpriseAddressBytes returns an enterprise address with a key hash of
+// repeated fill bytes on the given network.
+func enterpriseAddressBytes(networkId byte, fill byte) []byte {
+	return append([]byte{0x60 | networkId}, bytes.Repeat([]byte{fill}, 28)...)
+}
+
+func TestNetworkIdForMagic(t *testing.T) {
+	t.Parallel()
+	if got := NetworkIdForMagic(ouroboros.NetworkMainnet.NetworkMagic); got != lcommon.AddressNetworkMainnet {
+		t.Errorf("mainnet: want %d, got %d", lcommon.AddressNetworkMainnet, got)
+	}
+	if got := NetworkIdForMagic(ouroboros.NetworkPreview.NetworkMagic); got != lcommon.AddressNetworkTestnet {
+		t.Errorf("preview: want %d, got %d", lcommon.AddressNetworkTestnet, got)
+	}
+	if got := NetworkIdForMagic(12345); got != lcommon.AddressNetworkTestnet {
+		t.Errorf("custom network: want %d, got %d", lcommon.AddressNetworkTestnet, got)
+	}
+}
+
+func TestFindNetworkMismatches(t *testing.T) {
+	t.Parallel()
+	// Reward address: 0xe0 header (key hash stake address) plus network ID
+	rewardAddr := append([]byte{0xe0}, bytes.Repeat([]byte{0x22}, 28)...)
+
+	body := buildMinimalConwayBody()
+	body[1] = []map[uint]any{
+		{0: enterpriseAddressBytes(1, 0x01), 1: uint64(1_000_000)},
+		{0: enterpriseAddressBytes(0, 0x02), 1: uint64(1_000_000)},
+	}
+	body[5] = map[any]uint64{gocbor.NewByteString(rewardAddr): 1_000}
+	body[15] = uint(0)
+	txBytes := buildConwayTx(t, body, map[uint]any{})
+
+	mismatches, err := FindNetworkMismatches(txBytes, lcommon.AddressNetworkMainnet)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var fields []string
+	for _, m := range mismatches {
+		fields = append(fields, m.Field)
+	}
+	if strings.Join(fields, ",") != "output,withdrawal,network_id" {
+		t.Fatalf("unexpected mismatches: %+v", mismatches)
+	}
+	if mismatches[0].Index != 1 || !strings.HasPrefix(mismatches[0].Address, "addr_test1") {
+		t.Errorf("expected second output to mismatch, got %+v", mismatches[0])
+	}
+	if !strings.HasPrefix(mismatches[1].Address, "stake_test1") {
+		t.Errorf("expected testnet reward address, got %+v", mismatches[1])
+	}
+	if !strings.Contains(mismatches[0].String(), mismatches[0].Address) {
+		t.Errorf("expected message to name the address, got %q", mismatches[0].String())
+	}
+}
+
+func TestFindNetworkMismatches_Match(t *testing.T) {
+	t.Parallel()
+	body := buildMinimalConwayBody()
+	body[1] = []map[uint]any{{0: enterpriseAddressBytes(0, 0x01), 1: uint64(1_000_000)}}
+	txBytes := buildConwayTx(t, body, map[uint]any{})
+
+	mismatches, err := FindNetworkMismatches(txBytes, lcommon.AddressNetworkTestnet)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("expected no mismatches, got %+v", mismatches)
+	}
+}
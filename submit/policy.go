@@ -0,0 +1,471 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"gopkg.in/yaml.v2"
+)
+
+// Policy rule categories, in the order they are evaluated
+const (
+	PolicyAddresses        = "addresses"
+	PolicyStakeCredentials = "stakeCredentials"
+	PolicyPolicyIds        = "policyIds"
+	PolicyScriptHashes     = "scriptHashes"
+	PolicyMetadataLabels   = "metadataLabels"
+)
+
+var policyCategories = []string{
+	PolicyAddresses,
+	PolicyStakeCredentials,
+	PolicyPolicyIds,
+	PolicyScriptHashes,
+	PolicyMetadataLabels,
+}
+
+// PolicyList is an allow list and a deny list for one kind of value. A
+// transaction is refused if any of its values is on the deny list, or, when
+// the allow list is not empty, if any of its values is not on the allow list.
+type PolicyList struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// PolicyRules are admission rules for the content of a transaction.
+type PolicyRules struct {
+	// Addresses are bech32 addresses paid to by the outputs, spent from by the
+	// inputs and collateral, or withdrawn from.
+	Addresses PolicyList `yaml:"addresses"`
+	// StakeCredentials are hex key or script hashes, or bech32 stake
+	// addresses, in the delegation part of those addresses and in
+	// certificates.
+	StakeCredentials PolicyList `yaml:"stakeCredentials"`
+	// PolicyIds are the hex policy IDs of minted or burned assets.
+	PolicyIds PolicyList `yaml:"policyIds"`
+	// ScriptHashes are the hex hashes of scripts in the witness set and of
+	// the reference scripts of spent and reference inputs the transaction
+	// runs.
+	ScriptHashes PolicyList `yaml:"scriptHashes"`
+	// MetadataLabels are the top-level transaction metadata labels.
+	MetadataLabels PolicyList `yaml:"metadataLabels"`
+	// Expressions are checked after the lists, in order.
+	Expressions []AdmissionExpression `yaml:"expressions"`
+}
+
+func (r *PolicyRules) list(category string) PolicyList {
+	switch category {
+	case PolicyAddresses:
+		return r.Addresses
+	case PolicyStakeCredentials:
+		return r.StakeCredentials
+	case PolicyPolicyIds:
+		return r.PolicyIds
+	case PolicyScriptHashes:
+		return r.ScriptHashes
+	default:
+		return r.MetadataLabels
+	}
+}
+
+// LoadPolicyRules reads policy rules from a YAML file.
+func LoadPolicyRules(path string) (*PolicyRules, error) {
+	buf, err := os.ReadFile(path) // #nosec G304 -- rules file path comes from configuration
+	if err != nil {
+		return nil, fmt.Errorf("error reading policy rules file: %w", err)
+	}
+	rules := &PolicyRules{}
+	if err := yaml.UnmarshalStrict(buf, rules); err != nil {
+		return nil, fmt.Errorf("error parsing policy rules file: %w", err)
+	}
+	return rules, nil
+}
+
+// PolicyViolation identifies the rule that refused a transaction and the
+// value that matched it.
+type PolicyViolation struct {
+	// Rule is the category and list that matched, such as "addresses.deny"
+	// or "policyIds.allow", or "expressions.<name>" for an expression.
+	Rule string `json:"rule"`
+	// Value is the value that matched a list, or the message of an
+	// expression.
+	Value string `json:"value"`
+}
+
+func (v *PolicyViolation) Error() string {
+	if strings.HasPrefix(v.Rule, "expressions.") {
+		return fmt.Sprintf("transaction refused by policy rule %s: %s", v.Rule, v.Value)
+	}
+	if strings.HasSuffix(v.Rule, ".allow") {
+		return fmt.Sprintf("transaction refused by policy rule %s: %s is not allowed", v.Rule, v.Value)
+	}
+	return fmt.Sprintf("transaction refused by policy rule %s: %s is denied", v.Rule, v.Value)
+}
+
+type policySet map[string]struct{}
+
+// Policy is a compiled set of admission rules. It is safe for concurrent use.
+type Policy struct {
+	allow       map[string]policySet
+	deny        map[string]policySet
+	expressions []compiledExpression
+	observe     func(name, result string)
+}
+
+// NewPolicy compiles one or more sets of rules into a Policy. The lists of
+// each category are merged, and values are normalized so that equivalent
+// spellings of the same address or hash match.
+func NewPolicy(rules ...PolicyRules) (*Policy, error) {
+	p := &Policy{
+		allow: make(map[string]policySet),
+		deny:  make(map[string]policySet),
+	}
+	var exprs []AdmissionExpression
+	for _, r := range rules {
+		exprs = append(exprs, r.Expressions...)
+		for _, category := range policyCategories {
+			list := r.list(category)
+			if err := p.add(p.allow, category, "allow", list.Allow); err != nil {
+				return nil, err
+			}
+			if err := p.add(p.deny, category, "deny", list.Deny); err != nil {
+				return nil, err
+			}
+		}
+	}
+	compiled, err := compileAdmissionExpressions(exprs)
+	if err != nil {
+		return nil, err
+	}
+	p.expressions = compiled
+	return p, nil
+}
+
+// ObserveExpressions sets a function that is called with the name of each
+// evaluated expression and its result, one of "pass", "fail" or "error". It
+// must be called before the policy is used.
+func (p *Policy) ObserveExpressions(fn func(name, result string)) {
+	p.observe = fn
+}
+
+func (p *Policy) add(sets map[string]policySet, category, kind string, values []string) error {
+	for _, value := range values {
+		normalized, err := normalizePolicyValue(category, value)
+		if err != nil {
+			return fmt.Errorf("invalid value in policy rule %s.%s: %w", category, kind, err)
+		}
+		if sets[category] == nil {
+			sets[category] = make(policySet)
+		}
+		sets[category][normalized] = struct{}{}
+	}
+	return nil
+}
+
+// Empty reports whether the policy has no rules.
+func (p *Policy) Empty() bool {
+	return len(p.allow) == 0 && len(p.deny) == 0 && len(p.expressions) == 0
+}
+
+func (p *Policy) has(category string) bool {
+	return len(p.allow[category]) > 0 || len(p.deny[category]) > 0
+}
+
+func normalizePolicyValue(category, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch category {
+	case PolicyAddresses:
+		addr, err := lcommon.NewAddress(value)
+		if err != nil {
+			return "", fmt.Errorf("%q: %w", value, err)
+		}
+		return addr.String(), nil
+	case PolicyStakeCredentials:
+		if strings.HasPrefix(value, "stake") {
+			addr, err := lcommon.NewAddress(value)
+			if err != nil {
+				return "", fmt.Errorf("%q: %w", value, err)
+			}
+			cred, ok := addressStakeCredential(addr)
+			if !ok {
+				return "", fmt.Errorf("%q: not a stake address", value)
+			}
+			return cred, nil
+		}
+		return normalizeHash(value)
+	case PolicyPolicyIds, PolicyScriptHashes:
+		return normalizeHash(value)
+	default:
+		label, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q: metadata label must be an unsigned integer", value)
+		}
+		return strconv.FormatUint(label, 10), nil
+	}
+}
+
+// normalizeHash checks that value is a hex encoded 28 byte hash and returns
+// it in lower case.
+func normalizeHash(value string) (string, error) {
+	b, err := hex.DecodeString(value)
+	if err != nil || len(b) != lcommon.Blake2b224Size {
+		return "", fmt.Errorf("%q: must be a hex encoded %d byte hash", value, lcommon.Blake2b224Size)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// addressStakeCredential returns the hex hash of the stake credential in the
+// delegation part of an address, if it has one.
+func addressStakeCredential(addr lcommon.Address) (string, bool) {
+	switch p := addr.StakingPayload().(type) {
+	case lcommon.AddressPayloadKeyHash:
+		return p.Hash.String(), true
+	case lcommon.AddressPayloadScriptHash:
+		return p.Hash.String(), true
+	default:
+		return "", false
+	}
+}
+
+// Evaluate checks a transaction against the policy and returns the first
+// rule it violates, or nil if it is admitted. Deny lists are checked before
+// allow lists, and expressions last. An expression that fails to evaluate
+// refuses the transaction. resolve is used to find the addresses and reference scripts of
+// inputs, and may be nil to only consider the transaction itself.
+func (p *Policy) Evaluate(txRawBytes []byte, resolve UTxOResolver) (*PolicyViolation, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	values, err := p.txValues(tx, resolve)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range policyCategories {
+		for _, value := range values[category] {
+			if _, ok := p.deny[category][value]; ok {
+				return &PolicyViolation{Rule: category + ".deny", Value: value}, nil
+			}
+		}
+	}
+	for _, category := range policyCategories {
+		allow := p.allow[category]
+		if len(allow) == 0 {
+			continue
+		}
+		for _, value := range values[category] {
+			if _, ok := allow[value]; !ok {
+				return &PolicyViolation{Rule: category + ".allow", Value: value}, nil
+			}
+		}
+	}
+	if len(p.expressions) > 0 {
+		admissionTx := NewAdmissionTx(tx, len(txRawBytes))
+		for i := range p.expressions {
+			expr := &p.expressions[i]
+			admit, err := expr.eval(admissionTx)
+			switch {
+			case err != nil:
+				p.observeResult(expr.name, "error")
+				return &PolicyViolation{
+					Rule:  "expressions." + expr.name,
+					Value: "evaluation error: " + err.Error(),
+				}, nil
+			case !admit:
+				p.observeResult(expr.name, "fail")
+				return &PolicyViolation{Rule: "expressions." + expr.name, Value: expr.message}, nil
+			}
+			p.observeResult(expr.name, "pass")
+		}
+	}
+	return nil, nil
+}
+
+func (p *Policy) observeResult(name, result string) {
+	if p.observe != nil {
+		p.observe(name, result)
+	}
+}
+
+// txValues collects the sorted, distinct values of each category with rules
+// from a transaction.
+func (p *Policy) txValues(tx ledger.Transaction, resolve UTxOResolver) (map[string][]string, error) {
+	sets := make(map[string]policySet, len(policyCategories))
+	addValue := func(category, value string) {
+		if sets[category] == nil {
+			sets[category] = make(policySet)
+		}
+		sets[category][value] = struct{}{}
+	}
+	addAddress := func(addr lcommon.Address) {
+		addValue(PolicyAddresses, addr.String())
+		if cred, ok := addressStakeCredential(addr); ok {
+			addValue(PolicyStakeCredentials, cred)
+		}
+	}
+
+	if p.has(PolicyAddresses) || p.has(PolicyStakeCredentials) {
+		for _, output := range tx.Outputs() {
+			addAddress(output.Address())
+		}
+		if output := tx.CollateralReturn(); output != nil {
+			addAddress(output.Address())
+		}
+		for addr := range tx.Withdrawals() {
+			if addr != nil {
+				addAddress(*addr)
+			}
+		}
+		for _, cert := range tx.Certificates() {
+			if cred := certStakeCredential(cert); cred != nil {
+				addValue(PolicyStakeCredentials, cred.Credential.String())
+			}
+		}
+	}
+
+	if p.has(PolicyPolicyIds) {
+		if mint := tx.AssetMint(); mint != nil {
+			for _, policyId := range mint.Policies() {
+				addValue(PolicyPolicyIds, policyId.String())
+			}
+		}
+	}
+
+	if p.has(PolicyScriptHashes) {
+		for _, script := range witnessScripts(tx.Witnesses()) {
+			addValue(PolicyScriptHashes, script.Hash().String())
+		}
+	}
+
+	if p.has(PolicyMetadataLabels) {
+		if m, ok := tx.Metadata().(lcommon.MetaMap); ok {
+			for _, pair := range m.Pairs {
+				if label, ok := pair.Key.(lcommon.MetaInt); ok && label.Value != nil {
+					addValue(PolicyMetadataLabels, label.Value.String())
+				}
+			}
+		}
+	}
+
+	// Spent inputs and collateral contribute their addresses, and spent and
+	// reference inputs the reference scripts the transaction runs. A
+	// reference input can carry any script, so one the transaction doesn't
+	// run is not counted. Inputs missing from the UTxO set are left to the
+	// node to reject.
+	needAddresses := p.has(PolicyAddresses) || p.has(PolicyStakeCredentials)
+	needScripts := p.has(PolicyScriptHashes)
+	if resolve != nil && (needAddresses || needScripts) {
+		spent := slices.Concat(tx.Inputs(), tx.Collateral())
+		inputs := slices.Concat(spent, tx.ReferenceInputs())
+		if len(inputs) > 0 {
+			utxos, err := resolve(inputs)
+			if err != nil {
+				return nil, err
+			}
+			if needAddresses {
+				for _, input := range spent {
+					if output, ok := utxos[input.String()]; ok {
+						addAddress(output.Address())
+					}
+				}
+			}
+			if needScripts {
+				needed := scriptsNeeded(tx, utxos)
+				for _, input := range slices.Concat(tx.Inputs(), tx.ReferenceInputs()) {
+					output, ok := utxos[input.String()]
+					if !ok || output.ScriptRef() == nil {
+						continue
+					}
+					hash := output.ScriptRef().Hash()
+					if _, ok := needed[hash]; ok {
+						addValue(PolicyScriptHashes, hash.String())
+					}
+				}
+			}
+		}
+	}
+
+	ret := make(map[string][]string, len(sets))
+	for category, set := range sets {
+		values := make([]string, 0, len(set))
+		for value := range set {
+			values = append(values, value)
+		}
+		slices.Sort(values)
+		ret[category] = values
+	}
+	return ret, nil
+}
+
+// certStakeCredential returns the stake credential a certificate registers,
+// deregisters or delegates, if any.
+func certStakeCredential(cert lcommon.Certificate) *lcommon.Credential {
+	switch c := cert.(type) {
+	case *lcommon.StakeRegistrationCertificate:
+		return &c.StakeCredential
+	case *lcommon.StakeDeregistrationCertificate:
+		return &c.StakeCredential
+	case *lcommon.StakeDelegationCertificate:
+		return c.StakeCredential
+	case *lcommon.RegistrationCertificate:
+		return &c.StakeCredential
+	case *lcommon.DeregistrationCertificate:
+		return &c.StakeCredential
+	case *lcommon.VoteDelegationCertificate:
+		return &c.StakeCredential
+	case *lcommon.StakeVoteDelegationCertificate:
+		return &c.StakeCredential
+	case *lcommon.StakeRegistrationDelegationCertificate:
+		return &c.StakeCredential
+	case *lcommon.VoteRegistrationDelegationCertificate:
+		return &c.StakeCredential
+	case *lcommon.StakeVoteRegistrationDelegationCertificate:
+		return &c.StakeCredential
+	default:
+		return nil
+	}
+}
+
+// witnessScripts returns all scripts in a witness set.
+func witnessScripts(w lcommon.TransactionWitnessSet) []lcommon.Script {
+	if w == nil {
+		return nil
+	}
+	var ret []lcommon.Script
+	for _, s := range w.NativeScripts() {
+		ret = append(ret, s)
+	}
+	for _, s := range w.PlutusV1Scripts() {
+		ret = append(ret, s)
+	}
+	for _, s := range w.PlutusV2Scripts() {
+		ret = append(ret, s)
+	}
+	for _, s := range w.PlutusV3Scripts() {
+		ret = append(ret, s)
+	}
+	for _, s := range lcommon.PlutusV4ScriptsFromWitnessSet(w) {
+		ret = append(ret, s)
+	}
+	return ret
+}
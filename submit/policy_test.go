@@ -0,0 +1,328 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// testAddress builds a mainnet base address from a payment and stake key hash.
+func testAddress(t *testing.T, payment, stake byte) lcommon.Address {
+	t.Helper()
+	addrBytes := []byte{0x01}
+	addrBytes = append(addrBytes, bytes.Repeat([]byte{payment}, 28)...)
+	addrBytes = append(addrBytes, bytes.Repeat([]byte{stake}, 28)...)
+	addr, err := lcommon.NewAddressFromBytes(addrBytes)
+	if err != nil {
+		t.Fatalf("failed to build address: %s", err)
+	}
+	return addr
+}
+
+// buildPolicyTx builds a Conway transaction paying to addr, minting under
+// policy 0xcc.., with a native script witness and metadata label 674.
+func buildPolicyTx(t *testing.T, addr lcommon.Address) []byte {
+	t.Helper()
+	addrBytes, err := addr.Bytes()
+	if err != nil {
+		t.Fatalf("failed to encode address: %s", err)
+	}
+	body := buildMinimalConwayBody()
+	body[1] = []map[uint]any{{0: addrBytes, 1: uint64(2_000_000)}}
+	body[9] = map[any]any{
+		gocbor.NewByteString(bytes.Repeat([]byte{0xcc}, 28)): map[any]any{
+			gocbor.NewByteString([]byte("token")): int64(1),
+		},
+	}
+	witnesses := map[uint]any{1: []any{[]any{uint(0), bytes.Repeat([]byte{0x11}, 28)}}}
+	bodyBytes, err := gocbor.Encode(body)
+	if err != nil {
+		t.Fatalf("encode body: %v", err)
+	}
+	witnessBytes, err := gocbor.Encode(witnesses)
+	if err != nil {
+		t.Fatalf("encode witnesses: %v", err)
+	}
+	txBytes, err := gocbor.Encode([]any{
+		gocbor.RawMessage(bodyBytes),
+		gocbor.RawMessage(witnessBytes),
+		true,
+		map[uint]any{674: "hello"},
+	})
+	if err != nil {
+		t.Fatalf("encode tx: %v", err)
+	}
+	return txBytes
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+	payee := testAddress(t, 0x01, 0x02)
+	other := testAddress(t, 0x03, 0x04)
+	txBytes := buildPolicyTx(t, payee)
+	var nativeScript lcommon.NativeScript
+	if err := nativeScript.UnmarshalCBOR(mustEncode(t, []any{uint(0), bytes.Repeat([]byte{0x11}, 28)})); err != nil {
+		t.Fatalf("decode native script: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		rules    PolicyRules
+		wantRule string
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:     "denied output address",
+			rules:    PolicyRules{Addresses: PolicyList{Deny: []string{payee.String()}}},
+			wantRule: "addresses.deny",
+		},
+		{
+			name:  "allowed output address",
+			rules: PolicyRules{Addresses: PolicyList{Allow: []string{payee.String()}}},
+		},
+		{
+			name:     "output address not allowed",
+			rules:    PolicyRules{Addresses: PolicyList{Allow: []string{other.String()}}},
+			wantRule: "addresses.allow",
+		},
+		{
+			name:     "denied stake credential by hash",
+			rules:    PolicyRules{StakeCredentials: PolicyList{Deny: []string{strings.Repeat("02", 28)}}},
+			wantRule: "stakeCredentials.deny",
+		},
+		{
+			name:     "denied stake credential by stake address",
+			rules:    PolicyRules{StakeCredentials: PolicyList{Deny: []string{payee.StakeAddress().String()}}},
+			wantRule: "stakeCredentials.deny",
+		},
+		{
+			name:     "minting policy not allowed",
+			rules:    PolicyRules{PolicyIds: PolicyList{Allow: []string{strings.Repeat("dd", 28)}}},
+			wantRule: "policyIds.allow",
+		},
+		{
+			name:  "minting policy allowed in upper case",
+			rules: PolicyRules{PolicyIds: PolicyList{Allow: []string{strings.Repeat("CC", 28)}}},
+		},
+		{
+			name:     "denied witness script",
+			rules:    PolicyRules{ScriptHashes: PolicyList{Deny: []string{nativeScript.Hash().String()}}},
+			wantRule: "scriptHashes.deny",
+		},
+		{
+			name:     "denied metadata label",
+			rules:    PolicyRules{MetadataLabels: PolicyList{Deny: []string{"674"}}},
+			wantRule: "metadataLabels.deny",
+		},
+		{
+			name: "deny takes precedence over allow",
+			rules: PolicyRules{
+				Addresses:      PolicyList{Allow: []string{other.String()}},
+				MetadataLabels: PolicyList{Deny: []string{"674"}},
+			},
+			wantRule: "metadataLabels.deny",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := NewPolicy(tt.rules)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			violation, err := policy.Evaluate(txBytes, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantRule == "" {
+				if violation != nil {
+					t.Fatalf("expected transaction to be admitted, got %s", violation.Error())
+				}
+				return
+			}
+			if violation == nil || violation.Rule != tt.wantRule {
+				t.Fatalf("expected violation of %s, got %+v", tt.wantRule, violation)
+			}
+		})
+	}
+}
+
+func TestPolicy_EvaluateResolvedInputs(t *testing.T) {
+	t.Parallel()
+	sender := testAddress(t, 0x05, 0x06)
+	txBytes := buildPolicyTx(t, testAddress(t, 0x01, 0x02))
+	policy, err := NewPolicy(PolicyRules{Addresses: PolicyList{Deny: []string{sender.String()}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Without a resolver, only the transaction itself is considered
+	violation, err := policy.Evaluate(txBytes, nil)
+	if err != nil || violation != nil {
+		t.Fatalf("expected transaction to be admitted, got %+v, %v", violation, err)
+	}
+
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return map[string]ledger.TransactionOutput{
+			inputs[0].String(): babbage.BabbageTransactionOutput{OutputAddress: sender},
+		}, nil
+	}
+	violation, err = policy.Evaluate(txBytes, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if violation == nil || violation.Rule != "addresses.deny" || violation.Value != sender.String() {
+		t.Fatalf("expected spent input address to be denied, got %+v", violation)
+	}
+
+	_, err = policy.Evaluate(txBytes, func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if err == nil {
+		t.Error("expected resolver error to be returned")
+	}
+}
+
+func TestPolicy_EvaluateReferenceScripts(t *testing.T) {
+	t.Parallel()
+	refScript := lcommon.PlutusV3Script([]byte{0x01, 0x02, 0x03})
+	refHash := refScript.Hash()
+	refInput := []any{bytes.Repeat([]byte{0x01}, 32), uint32(0)}
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		ret := make(map[string]ledger.TransactionOutput, len(inputs))
+		for _, input := range inputs {
+			output := babbage.BabbageTransactionOutput{OutputAddress: testAddress(t, 0x05, 0x06)}
+			if input.Index() == 0 && bytes.Equal(input.Id().Bytes(), refInput[0].([]byte)) {
+				output.TxOutScriptRef = &lcommon.ScriptRef{Type: lcommon.ScriptRefTypePlutusV3, Script: refScript}
+			}
+			ret[input.String()] = output
+		}
+		return ret, nil
+	}
+	// refTx mints under policyId with a reference input holding refScript
+	refTx := func(policyId []byte) []byte {
+		body := buildMinimalConwayBody()
+		body[9] = map[any]any{
+			gocbor.NewByteString(policyId): map[any]any{
+				gocbor.NewByteString([]byte("token")): int64(1),
+			},
+		}
+		body[18] = [][]any{refInput}
+		return buildConwayTx(t, body, map[uint]any{})
+	}
+	policy, err := NewPolicy(PolicyRules{ScriptHashes: PolicyList{Deny: []string{refHash.String()}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The reference script is not run by a transaction minting under
+	// another policy
+	violation, err := policy.Evaluate(refTx(bytes.Repeat([]byte{0xcc}, 28)), resolve)
+	if err != nil || violation != nil {
+		t.Fatalf("expected unused reference script to be ignored, got %+v, %v", violation, err)
+	}
+
+	violation, err = policy.Evaluate(refTx(refHash.Bytes()), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if violation == nil || violation.Rule != "scriptHashes.deny" || violation.Value != refHash.String() {
+		t.Fatalf("expected minting reference script to be denied, got %+v", violation)
+	}
+
+	// An unrelated reference script doesn't break a script hash allow list
+	allowPolicy, err := NewPolicy(PolicyRules{ScriptHashes: PolicyList{Allow: []string{strings.Repeat("dd", 28)}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	violation, err = allowPolicy.Evaluate(refTx(bytes.Repeat([]byte{0xcc}, 28)), resolve)
+	if err != nil || violation != nil {
+		t.Fatalf("expected transaction to be admitted, got %+v, %v", violation, err)
+	}
+	violation, err = allowPolicy.Evaluate(refTx(refHash.Bytes()), resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if violation == nil || violation.Rule != "scriptHashes.allow" {
+		t.Fatalf("expected reference script off the allow list to be refused, got %+v", violation)
+	}
+}
+
+func TestNewPolicy_InvalidValues(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		rules PolicyRules
+	}{
+		{"address", PolicyRules{Addresses: PolicyList{Deny: []string{"addr1notvalid"}}}},
+		{"stake credential", PolicyRules{StakeCredentials: PolicyList{Deny: []string{"abcd"}}}},
+		{"policy ID", PolicyRules{PolicyIds: PolicyList{Allow: []string{"not-hex"}}}},
+		{"script hash", PolicyRules{ScriptHashes: PolicyList{Deny: []string{strings.Repeat("00", 32)}}}},
+		{"metadata label", PolicyRules{MetadataLabels: PolicyList{Deny: []string{"-1"}}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewPolicy(tt.rules); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
+
+func TestLoadPolicyRules(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	content := "metadataLabels:\n  deny:\n    - 674\npolicyIds:\n  allow:\n    - " + strings.Repeat("cc", 28) + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %s", err)
+	}
+	rules, err := LoadPolicyRules(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rules.MetadataLabels.Deny) != 1 || rules.MetadataLabels.Deny[0] != "674" {
+		t.Errorf("unexpected metadata label rules: %+v", rules.MetadataLabels)
+	}
+	if len(rules.PolicyIds.Allow) != 1 {
+		t.Errorf("unexpected policy ID rules: %+v", rules.PolicyIds)
+	}
+
+	if err := os.WriteFile(path, []byte("unknownField: true\n"), 0o600); err != nil {
+		t.Fatalf("failed to write rules file: %s", err)
+	}
+	if _, err := LoadPolicyRules(path); err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
+
+func mustEncode(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := gocbor.Encode(v)
+	if err != nil {
+		t.Fatalf("encode: %s", err)
+	}
+	return b
+}
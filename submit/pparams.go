@@ -0,0 +1,350 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// Ratio is an exact rational protocol parameter. It is rendered in JSON as a
+// decimal number, as cardano-cli does.
+type Ratio big.Rat
+
+// Rat returns r as a *big.Rat.
+func (r *Ratio) Rat() *big.Rat {
+	return (*big.Rat)(r)
+}
+
+func (r *Ratio) MarshalJSON() ([]byte, error) {
+	f, _ := r.Rat().Float64()
+	return json.Marshal(f)
+}
+
+func newRatio(r *cbor.Rat) *Ratio {
+	if r == nil || r.Rat == nil {
+		return nil
+	}
+	return (*Ratio)(new(big.Rat).Set(r.Rat))
+}
+
+type ProtocolVersion struct {
+	Major uint `json:"major"`
+	Minor uint `json:"minor"`
+}
+
+type ExecutionUnitPrices struct {
+	PriceMemory *Ratio `json:"priceMemory"`
+	PriceSteps  *Ratio `json:"priceSteps"`
+}
+
+type PoolVotingThresholds struct {
+	CommitteeNoConfidence *Ratio `json:"committeeNoConfidence"`
+	CommitteeNormal       *Ratio `json:"committeeNormal"`
+	HardForkInitiation    *Ratio `json:"hardForkInitiation"`
+	MotionNoConfidence    *Ratio `json:"motionNoConfidence"`
+	PpSecurityGroup       *Ratio `json:"ppSecurityGroup"`
+}
+
+type DRepVotingThresholds struct {
+	CommitteeNoConfidence *Ratio `json:"committeeNoConfidence"`
+	CommitteeNormal       *Ratio `json:"committeeNormal"`
+	HardForkInitiation    *Ratio `json:"hardForkInitiation"`
+	MotionNoConfidence    *Ratio `json:"motionNoConfidence"`
+	PpEconomicGroup       *Ratio `json:"ppEconomicGroup"`
+	PpGovGroup            *Ratio `json:"ppGovGroup"`
+	PpNetworkGroup        *Ratio `json:"ppNetworkGroup"`
+	PpTechnicalGroup      *Ratio `json:"ppTechnicalGroup"`
+	TreasuryWithdrawal    *Ratio `json:"treasuryWithdrawal"`
+	UpdateToConstitution  *Ratio `json:"updateToConstitution"`
+}
+
+// ProtocolParams holds the protocol parameters of the current era. The JSON
+// field layout matches the output of cardano-cli query protocol-parameters,
+// and fields that do not exist in the current era are omitted.
+type ProtocolParams struct {
+	// Era and Epoch are the era and epoch the parameters were queried in.
+	Era   ledger.Era `json:"-"`
+	Epoch uint64     `json:"-"`
+
+	TxFeePerByte        uint64          `json:"txFeePerByte"`
+	TxFeeFixed          uint64          `json:"txFeeFixed"`
+	MaxBlockBodySize    uint64          `json:"maxBlockBodySize"`
+	MaxTxSize           uint64          `json:"maxTxSize"`
+	MaxBlockHeaderSize  uint64          `json:"maxBlockHeaderSize"`
+	StakeAddressDeposit uint64          `json:"stakeAddressDeposit"`
+	StakePoolDeposit    uint64          `json:"stakePoolDeposit"`
+	PoolRetireMaxEpoch  uint64          `json:"poolRetireMaxEpoch"`
+	StakePoolTargetNum  uint64          `json:"stakePoolTargetNum"`
+	PoolPledgeInfluence *Ratio          `json:"poolPledgeInfluence"`
+	MonetaryExpansion   *Ratio          `json:"monetaryExpansion"`
+	TreasuryCut         *Ratio          `json:"treasuryCut"`
+	Decentralization    *Ratio          `json:"decentralization,omitempty"`
+	ProtocolVersion     ProtocolVersion `json:"protocolVersion"`
+	MinUTxOValue        *uint64         `json:"minUTxOValue,omitempty"`
+	MinPoolCost         uint64          `json:"minPoolCost"`
+
+	// Alonzo and later
+	UtxoCostPerWord        *uint64              `json:"utxoCostPerWord,omitempty"`
+	UtxoCostPerByte        *uint64              `json:"utxoCostPerByte,omitempty"`
+	CostModels             map[string][]int64   `json:"costModels,omitempty"`
+	ExecutionUnitPrices    *ExecutionUnitPrices `json:"executionUnitPrices,omitempty"`
+	MaxTxExecutionUnits    *lcommon.ExUnits     `json:"maxTxExecutionUnits,omitempty"`
+	MaxBlockExecutionUnits *lcommon.ExUnits     `json:"maxBlockExecutionUnits,omitempty"`
+	MaxValueSize           *uint64              `json:"maxValueSize,omitempty"`
+	CollateralPercentage   *uint64              `json:"collateralPercentage,omitempty"`
+	MaxCollateralInputs    *uint64              `json:"maxCollateralInputs,omitempty"`
+
+	// Conway and later
+	PoolVotingThresholds       *PoolVotingThresholds `json:"poolVotingThresholds,omitempty"`
+	DRepVotingThresholds       *DRepVotingThresholds `json:"dRepVotingThresholds,omitempty"`
+	CommitteeMinSize           *uint64               `json:"committeeMinSize,omitempty"`
+	CommitteeMaxTermLength     *uint64               `json:"committeeMaxTermLength,omitempty"`
+	GovActionLifetime          *uint64               `json:"govActionLifetime,omitempty"`
+	GovActionDeposit           *uint64               `json:"govActionDeposit,omitempty"`
+	DRepDeposit                *uint64               `json:"dRepDeposit,omitempty"`
+	DRepActivity               *uint64               `json:"dRepActivity,omitempty"`
+	MinFeeRefScriptCostPerByte *Ratio                `json:"minFeeRefScriptCostPerByte,omitempty"`
+}
+
+// QueryProtocolParams queries the protocol parameters of the current era,
+// along with the current epoch, over LocalStateQuery.
+func QueryProtocolParams(cfg *Config) (*ProtocolParams, error) {
+	oConn, err := dialStateQuery(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oConn.Close()
+
+	client := oConn.LocalStateQuery().Client
+	eraId, err := client.GetCurrentEra()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting current era: %w", err)
+	}
+	// The Byron era has no LocalStateQuery protocol parameters query
+	if eraId < ledger.EraIdShelley || eraId > math.MaxUint8 {
+		return nil, fmt.Errorf("protocol parameters are not available in era %d", eraId)
+	}
+	epochNo, err := client.GetEpochNo()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting epoch number: %w", err)
+	}
+	if epochNo < 0 {
+		return nil, fmt.Errorf("invalid epoch %d reported by node", epochNo)
+	}
+	pparams, err := client.GetCurrentProtocolParams()
+	if err != nil {
+		return nil, fmt.Errorf("failure getting protocol parameters: %w", err)
+	}
+	ret, err := protocolParamsFromLedger(pparams)
+	if err != nil {
+		return nil, err
+	}
+	ret.Era = ledger.GetEraById(uint8(eraId))
+	ret.Epoch = uint64(epochNo)
+	return ret, nil
+}
+
+// protocolParamsFromLedger converts the era-specific ledger protocol
+// parameters returned by the node into a ProtocolParams.
+func protocolParamsFromLedger(pparams lcommon.ProtocolParameters) (*ProtocolParams, error) {
+	switch p := pparams.(type) {
+	case *ledger.DijkstraProtocolParameters:
+		return conwayProtocolParams(&p.ConwayProtocolParameters), nil
+	case *ledger.ConwayProtocolParameters:
+		return conwayProtocolParams(p), nil
+	case *ledger.BabbageProtocolParameters:
+		ret := &ProtocolParams{
+			TxFeePerByte:           uint64(p.MinFeeA),
+			TxFeeFixed:             uint64(p.MinFeeB),
+			MaxBlockBodySize:       uint64(p.MaxBlockBodySize),
+			MaxTxSize:              uint64(p.MaxTxSize),
+			MaxBlockHeaderSize:     uint64(p.MaxBlockHeaderSize),
+			StakeAddressDeposit:    uint64(p.KeyDeposit),
+			StakePoolDeposit:       uint64(p.PoolDeposit),
+			PoolRetireMaxEpoch:     uint64(p.MaxEpoch),
+			StakePoolTargetNum:     uint64(p.NOpt),
+			PoolPledgeInfluence:    newRatio(p.A0),
+			MonetaryExpansion:      newRatio(p.Rho),
+			TreasuryCut:            newRatio(p.Tau),
+			ProtocolVersion:        ProtocolVersion{Major: p.ProtocolMajor, Minor: p.ProtocolMinor},
+			MinPoolCost:            p.MinPoolCost,
+			UtxoCostPerByte:        &p.AdaPerUtxoByte,
+			CostModels:             costModelsByLanguage(p.CostModels),
+			ExecutionUnitPrices:    executionUnitPrices(p.ExecutionCosts),
+			MaxTxExecutionUnits:    &p.MaxTxExUnits,
+			MaxBlockExecutionUnits: &p.MaxBlockExUnits,
+			MaxValueSize:           uintPtr(p.MaxValueSize),
+			CollateralPercentage:   uintPtr(p.CollateralPercentage),
+			MaxCollateralInputs:    uintPtr(p.MaxCollateralInputs),
+		}
+		return ret, nil
+	case *ledger.AlonzoProtocolParameters:
+		ret := &ProtocolParams{
+			TxFeePerByte:        uint64(p.MinFeeA),
+			TxFeeFixed:          uint64(p.MinFeeB),
+			MaxBlockBodySize:    uint64(p.MaxBlockBodySize),
+			MaxTxSize:           uint64(p.MaxTxSize),
+			MaxBlockHeaderSize:  uint64(p.MaxBlockHeaderSize),
+			StakeAddressDeposit: uint64(p.KeyDeposit),
+			StakePoolDeposit:    uint64(p.PoolDeposit),
+			PoolRetireMaxEpoch:  uint64(p.MaxEpoch),
+			StakePoolTargetNum:  uint64(p.NOpt),
+			PoolPledgeInfluence: newRatio(p.A0),
+			MonetaryExpansion:   newRatio(p.Rho),
+			TreasuryCut:         newRatio(p.Tau),
+			Decentralization:    newRatio(p.Decentralization),
+			ProtocolVersion:     ProtocolVersion{Major: p.ProtocolMajor, Minor: p.ProtocolMinor},
+			MinUTxOValue:        uintPtr(p.MinUtxoValue),
+			MinPoolCost:         p.MinPoolCost,
+			// The Alonzo ledger charges for UTxO size per 8-byte word
+			UtxoCostPerWord:        &p.AdaPerUtxoByte,
+			CostModels:             costModelsByLanguage(p.CostModels),
+			ExecutionUnitPrices:    executionUnitPrices(p.ExecutionCosts),
+			MaxTxExecutionUnits:    &p.MaxTxExUnits,
+			MaxBlockExecutionUnits: &p.MaxBlockExUnits,
+			MaxValueSize:           uintPtr(p.MaxValueSize),
+			CollateralPercentage:   uintPtr(p.CollateralPercentage),
+			MaxCollateralInputs:    uintPtr(p.MaxCollateralInputs),
+		}
+		return ret, nil
+	case *ledger.MaryProtocolParameters:
+		ret := &ProtocolParams{
+			TxFeePerByte:        uint64(p.MinFeeA),
+			TxFeeFixed:          uint64(p.MinFeeB),
+			MaxBlockBodySize:    uint64(p.MaxBlockBodySize),
+			MaxTxSize:           uint64(p.MaxTxSize),
+			MaxBlockHeaderSize:  uint64(p.MaxBlockHeaderSize),
+			StakeAddressDeposit: uint64(p.KeyDeposit),
+			StakePoolDeposit:    uint64(p.PoolDeposit),
+			PoolRetireMaxEpoch:  uint64(p.MaxEpoch),
+			StakePoolTargetNum:  uint64(p.NOpt),
+			PoolPledgeInfluence: newRatio(p.A0),
+			MonetaryExpansion:   newRatio(p.Rho),
+			TreasuryCut:         newRatio(p.Tau),
+			Decentralization:    newRatio(p.Decentralization),
+			ProtocolVersion:     ProtocolVersion{Major: p.ProtocolMajor, Minor: p.ProtocolMinor},
+			MinUTxOValue:        uintPtr(p.MinUtxoValue),
+			MinPoolCost:         p.MinPoolCost,
+		}
+		return ret, nil
+	case *ledger.ShelleyProtocolParameters:
+		// Allegra shares the Shelley protocol parameters type
+		ret := &ProtocolParams{
+			TxFeePerByte:        uint64(p.MinFeeA),
+			TxFeeFixed:          uint64(p.MinFeeB),
+			MaxBlockBodySize:    uint64(p.MaxBlockBodySize),
+			MaxTxSize:           uint64(p.MaxTxSize),
+			MaxBlockHeaderSize:  uint64(p.MaxBlockHeaderSize),
+			StakeAddressDeposit: uint64(p.KeyDeposit),
+			StakePoolDeposit:    uint64(p.PoolDeposit),
+			PoolRetireMaxEpoch:  uint64(p.MaxEpoch),
+			StakePoolTargetNum:  uint64(p.NOpt),
+			PoolPledgeInfluence: newRatio(p.A0),
+			MonetaryExpansion:   newRatio(p.Rho),
+			TreasuryCut:         newRatio(p.Tau),
+			Decentralization:    newRatio(p.Decentralization),
+			ProtocolVersion:     ProtocolVersion{Major: p.ProtocolMajor, Minor: p.ProtocolMinor},
+			MinUTxOValue:        uintPtr(p.MinUtxoValue),
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("unsupported protocol parameters type %T", pparams)
+	}
+}
+
+func conwayProtocolParams(p *ledger.ConwayProtocolParameters) *ProtocolParams {
+	return &ProtocolParams{
+		TxFeePerByte:           uint64(p.MinFeeA),
+		TxFeeFixed:             uint64(p.MinFeeB),
+		MaxBlockBodySize:       uint64(p.MaxBlockBodySize),
+		MaxTxSize:              uint64(p.MaxTxSize),
+		MaxBlockHeaderSize:     uint64(p.MaxBlockHeaderSize),
+		StakeAddressDeposit:    uint64(p.KeyDeposit),
+		StakePoolDeposit:       uint64(p.PoolDeposit),
+		PoolRetireMaxEpoch:     uint64(p.MaxEpoch),
+		StakePoolTargetNum:     uint64(p.NOpt),
+		PoolPledgeInfluence:    newRatio(p.A0),
+		MonetaryExpansion:      newRatio(p.Rho),
+		TreasuryCut:            newRatio(p.Tau),
+		ProtocolVersion:        ProtocolVersion{Major: p.ProtocolVersion.Major, Minor: p.ProtocolVersion.Minor},
+		MinPoolCost:            p.MinPoolCost,
+		UtxoCostPerByte:        &p.AdaPerUtxoByte,
+		CostModels:             costModelsByLanguage(p.CostModels),
+		ExecutionUnitPrices:    executionUnitPrices(p.ExecutionCosts),
+		MaxTxExecutionUnits:    &p.MaxTxExUnits,
+		MaxBlockExecutionUnits: &p.MaxBlockExUnits,
+		MaxValueSize:           uintPtr(p.MaxValueSize),
+		CollateralPercentage:   uintPtr(p.CollateralPercentage),
+		MaxCollateralInputs:    uintPtr(p.MaxCollateralInputs),
+		PoolVotingThresholds: &PoolVotingThresholds{
+			CommitteeNoConfidence: newRatio(&p.PoolVotingThresholds.CommitteeNoConfidence),
+			CommitteeNormal:       newRatio(&p.PoolVotingThresholds.CommitteeNormal),
+			HardForkInitiation:    newRatio(&p.PoolVotingThresholds.HardForkInitiation),
+			MotionNoConfidence:    newRatio(&p.PoolVotingThresholds.MotionNoConfidence),
+			PpSecurityGroup:       newRatio(&p.PoolVotingThresholds.PpSecurityGroup),
+		},
+		DRepVotingThresholds: &DRepVotingThresholds{
+			CommitteeNoConfidence: newRatio(&p.DRepVotingThresholds.CommitteeNoConfidence),
+			CommitteeNormal:       newRatio(&p.DRepVotingThresholds.CommitteeNormal),
+			HardForkInitiation:    newRatio(&p.DRepVotingThresholds.HardForkInitiation),
+			MotionNoConfidence:    newRatio(&p.DRepVotingThresholds.MotionNoConfidence),
+			PpEconomicGroup:       newRatio(&p.DRepVotingThresholds.PpEconomicGroup),
+			PpGovGroup:            newRatio(&p.DRepVotingThresholds.PpGovGroup),
+			PpNetworkGroup:        newRatio(&p.DRepVotingThresholds.PpNetworkGroup),
+			PpTechnicalGroup:      newRatio(&p.DRepVotingThresholds.PpTechnicalGroup),
+			TreasuryWithdrawal:    newRatio(&p.DRepVotingThresholds.TreasuryWithdrawal),
+			UpdateToConstitution:  newRatio(&p.DRepVotingThresholds.UpdateToConstitution),
+		},
+		CommitteeMinSize:           uintPtr(p.MinCommitteeSize),
+		CommitteeMaxTermLength:     &p.CommitteeTermLimit,
+		GovActionLifetime:          &p.GovActionValidityPeriod,
+		GovActionDeposit:           &p.GovActionDeposit,
+		DRepDeposit:                &p.DRepDeposit,
+		DRepActivity:               &p.DRepInactivityPeriod,
+		MinFeeRefScriptCostPerByte: newRatio(p.MinFeeRefScriptCostPerByte),
+	}
+}
+
+// costModelsByLanguage keys cost models by Plutus language name rather than
+// the ledger language ID.
+func costModelsByLanguage(models map[uint][]int64) map[string][]int64 {
+	if len(models) == 0 {
+		return nil
+	}
+	ret := make(map[string][]int64, len(models))
+	for lang, model := range models {
+		ret[fmt.Sprintf("PlutusV%d", lang+1)] = model
+	}
+	return ret
+}
+
+func executionUnitPrices(p lcommon.ExUnitPrice) *ExecutionUnitPrices {
+	return &ExecutionUnitPrices{
+		PriceMemory: newRatio(p.MemPrice),
+		PriceSteps:  newRatio(p.StepPrice),
+	}
+}
+
+func uintPtr(v uint) *uint64 {
+	ret := uint64(v)
+	return &ret
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func rat(num, denom int64) *cbor.Rat {
+	return &cbor.Rat{Rat: big.NewRat(num, denom)}
+}
+
+func TestProtocolParamsFromLedger_Conway(t *testing.T) {
+	t.Parallel()
+	p := &ledger.ConwayProtocolParameters{
+		MinFeeA:              44,
+		MinFeeB:              155381,
+		MaxTxSize:            16384,
+		KeyDeposit:           2000000,
+		A0:                   rat(3, 10),
+		Rho:                  rat(3, 1000),
+		Tau:                  rat(1, 5),
+		ProtocolVersion:      lcommon.ProtocolParametersProtocolVersion{Major: 10},
+		MinPoolCost:          170000000,
+		AdaPerUtxoByte:       4310,
+		CostModels:           map[uint][]int64{0: {1, 2}, 2: {3}},
+		ExecutionCosts:       lcommon.ExUnitPrice{MemPrice: rat(577, 10000), StepPrice: rat(721, 10000000)},
+		MaxTxExUnits:         lcommon.ExUnits{Memory: 14000000, Steps: 10000000000},
+		CollateralPercentage: 150,
+		GovActionDeposit:     100000000000,
+		// Ref script fees are charged at 15 lovelace per byte
+		MinFeeRefScriptCostPerByte: rat(15, 1),
+	}
+	params, err := protocolParamsFromLedger(p)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	out, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]any{
+		"txFeePerByte":               float64(44),
+		"txFeeFixed":                 float64(155381),
+		"maxTxSize":                  float64(16384),
+		"stakeAddressDeposit":        float64(2000000),
+		"poolPledgeInfluence":        0.3,
+		"monetaryExpansion":          0.003,
+		"treasuryCut":                0.2,
+		"minPoolCost":                float64(170000000),
+		"utxoCostPerByte":            float64(4310),
+		"collateralPercentage":       float64(150),
+		"govActionDeposit":           float64(100000000000),
+		"minFeeRefScriptCostPerByte": float64(15),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: want %v, got %v", k, v, got[k])
+		}
+	}
+	for _, k := range []string{"decentralization", "minUTxOValue", "utxoCostPerWord"} {
+		if _, ok := got[k]; ok {
+			t.Errorf("unexpected pre-Babbage field %s", k)
+		}
+	}
+	prices := got["executionUnitPrices"].(map[string]any)
+	if prices["priceMemory"] != 0.0577 || prices["priceSteps"] != 7.21e-05 {
+		t.Errorf("unexpected execution unit prices: %v", prices)
+	}
+	models := got["costModels"].(map[string]any)
+	if _, ok := models["PlutusV1"]; !ok {
+		t.Error("expected PlutusV1 cost model")
+	}
+	if _, ok := models["PlutusV3"]; !ok {
+		t.Error("expected PlutusV3 cost model")
+	}
+	if params.ProtocolVersion.Major != 10 {
+		t.Errorf("unexpected protocol version %+v", params.ProtocolVersion)
+	}
+}
+
+func TestProtocolParamsFromLedger_Alonzo(t *testing.T) {
+	t.Parallel()
+	params, err := protocolParamsFromLedger(&ledger.AlonzoProtocolParameters{
+		Decentralization: rat(0, 1),
+		ProtocolMajor:    6,
+		AdaPerUtxoByte:   34482,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if params.UtxoCostPerWord == nil || *params.UtxoCostPerWord != 34482 {
+		t.Errorf("expected utxoCostPerWord 34482, got %v", params.UtxoCostPerWord)
+	}
+	if params.UtxoCostPerByte != nil {
+		t.Error("expected no utxoCostPerByte in Alonzo")
+	}
+	if params.Decentralization == nil {
+		t.Error("expected decentralization in Alonzo")
+	}
+}
+
+func TestProtocolParamsFromLedger_Shelley(t *testing.T) {
+	t.Parallel()
+	params, err := protocolParamsFromLedger(&ledger.ShelleyProtocolParameters{
+		MinFeeA:      44,
+		MinUtxoValue: 1000000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if params.MinUTxOValue == nil || *params.MinUTxOValue != 1000000 {
+		t.Errorf("expected minUTxOValue 1000000, got %v", params.MinUTxOValue)
+	}
+	if params.CostModels != nil || params.ExecutionUnitPrices != nil {
+		t.Error("expected no Plutus parameters in Shelley")
+	}
+}
+
+func TestRatio_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	r := newRatio(rat(1, 4))
+	out, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(out) != "0.25" {
+		t.Errorf("expected 0.25, got %s", out)
+	}
+	if r.Rat().Cmp(big.NewRat(1, 4)) != 0 {
+		t.Errorf("expected exact value 1/4, got %s", r.Rat())
+	}
+	if newRatio(nil) != nil {
+		t.Error("expected nil ratio for nil input")
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/ledger/shelley"
+	"github.com/blinklabs-io/gouroboros/protocol/localstatequery"
+)
+
+// EraSummary describes the slot and time bounds of a single hard-fork era.
+// StartTime is relative to the network system start. The last era in a
+// summary list is open-ended for conversion purposes.
+type EraSummary struct {
+	StartSlot   uint64
+	StartEpoch  uint64
+	StartTime   time.Duration
+	SlotLength  time.Duration
+	EpochLength uint64
+}
+
+// SlotClock converts between slot numbers and wall-clock time using the era
+// summaries of a network. It implements the ledger SlotState interface.
+type SlotClock struct {
+	SystemStart time.Time
+	Eras        []EraSummary
+}
+
+// NewSlotClock returns a SlotClock for the given system start and era
+// summaries, which must be ordered by start slot.
+func NewSlotClock(systemStart time.Time, eras []EraSummary) (*SlotClock, error) {
+	if len(eras) == 0 {
+		return nil, errors.New("no era summaries provided")
+	}
+	for i, era := range eras {
+		if era.SlotLength <= 0 {
+			return nil, fmt.Errorf("era %d has invalid slot length %s", i, era.SlotLength)
+		}
+		if i > 0 && era.StartSlot < eras[i-1].StartSlot {
+			return nil, fmt.Errorf("era %d starts before the previous era", i)
+		}
+	}
+	return &SlotClock{SystemStart: systemStart, Eras: eras}, nil
+}
+
+// eraForSlot returns the summary of the era containing slot.
+func (c *SlotClock) eraForSlot(slot uint64) (EraSummary, error) {
+	if len(c.Eras) == 0 {
+		return EraSummary{}, errors.New("slot clock has no eras")
+	}
+	if slot < c.Eras[0].StartSlot {
+		return EraSummary{}, fmt.Errorf("slot %d is before the first known era", slot)
+	}
+	ret := c.Eras[0]
+	for _, era := range c.Eras[1:] {
+		if slot < era.StartSlot {
+			break
+		}
+		ret = era
+	}
+	return ret, nil
+}
+
+// SlotToTime returns the wall-clock start time of slot.
+func (c *SlotClock) SlotToTime(slot uint64) (time.Time, error) {
+	era, err := c.eraForSlot(slot)
+	if err != nil {
+		return time.Time{}, err
+	}
+	offset := time.Duration(slot-era.StartSlot) * era.SlotLength // #nosec G115
+	return c.SystemStart.Add(era.StartTime + offset), nil
+}
+
+// SlotToEpoch returns the epoch containing slot.
+func (c *SlotClock) SlotToEpoch(slot uint64) (uint64, error) {
+	era, err := c.eraForSlot(slot)
+	if err != nil {
+		return 0, err
+	}
+	if era.EpochLength == 0 {
+		return 0, errors.New("era has no epoch length")
+	}
+	return era.StartEpoch + (slot-era.StartSlot)/era.EpochLength, nil
+}
+
+// EpochStartSlot returns the first slot of epoch.
+func (c *SlotClock) EpochStartSlot(epoch uint64) (uint64, error) {
+	if len(c.Eras) == 0 {
+		return 0, errors.New("slot clock has no eras")
+	}
+	if epoch < c.Eras[0].StartEpoch {
+		return 0, fmt.Errorf("epoch %d is before the first known era", epoch)
+	}
+	era := c.Eras[0]
+	for _, e := range c.Eras[1:] {
+		if epoch < e.StartEpoch {
+			break
+		}
+		era = e
+	}
+	return era.StartSlot + (epoch-era.StartEpoch)*era.EpochLength, nil
+}
+
+// TimeToSlot returns the slot in progress at t.
+func (c *SlotClock) TimeToSlot(t time.Time) (uint64, error) {
+	if len(c.Eras) == 0 {
+		return 0, errors.New("slot clock has no eras")
+	}
+	rel := t.Sub(c.SystemStart)
+	if rel < c.Eras[0].StartTime {
+		return 0, fmt.Errorf("time %s is before the first known era", t.UTC().Format(time.RFC3339))
+	}
+	era := c.Eras[0]
+	for _, e := range c.Eras[1:] {
+		if rel < e.StartTime {
+			break
+		}
+		era = e
+	}
+	return era.StartSlot + uint64((rel-era.StartTime)/era.SlotLength), nil // #nosec G115
+}
+
+// slotClockFromNode builds a SlotClock from the LocalStateQuery system start
+// and hard-fork era history results.
+func slotClockFromNode(
+	systemStart *localstatequery.SystemStartResult,
+	history []localstatequery.EraHistoryResult,
+) (*SlotClock, error) {
+	start, err := systemStartTime(systemStart)
+	if err != nil {
+		return nil, err
+	}
+	eras := make([]EraSummary, 0, len(history))
+	for _, h := range history {
+		startTime, err := picosecondsToDuration(h.Begin.Timespan)
+		if err != nil {
+			return nil, fmt.Errorf("invalid era start time: %w", err)
+		}
+		if h.Begin.SlotNo < 0 || h.Begin.EpochNo < 0 || h.Params.EpochLength < 0 {
+			return nil, errors.New("invalid era history: negative slot, epoch or epoch length")
+		}
+		eras = append(eras, EraSummary{
+			StartSlot:   uint64(h.Begin.SlotNo),
+			StartEpoch:  uint64(h.Begin.EpochNo),
+			StartTime:   startTime,
+			SlotLength:  time.Duration(h.Params.SlotLength) * time.Millisecond,
+			EpochLength: uint64(h.Params.EpochLength),
+		})
+	}
+	return NewSlotClock(start, eras)
+}
+
+// SlotClockFromShelleyGenesis builds a SlotClock from the system start, slot
+// length and epoch length of a Shelley genesis file. The network is assumed to
+// start in the Shelley era or later, with no Byron slots, as is usual for
+// custom and development networks.
+func SlotClockFromShelleyGenesis(path string) (*SlotClock, error) {
+	genesis, err := shelley.NewShelleyGenesisFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Shelley genesis: %w", err)
+	}
+	if genesis.SlotLength.Rat == nil || genesis.SlotLength.Sign() <= 0 {
+		return nil, errors.New("invalid Shelley genesis: missing slot length")
+	}
+	if genesis.EpochLength <= 0 {
+		return nil, errors.New("invalid Shelley genesis: missing epoch length")
+	}
+	// The slot length is given in seconds, which may be fractional
+	slotLength := new(big.Rat).Mul(genesis.SlotLength.Rat, big.NewRat(int64(time.Second), 1))
+	if !slotLength.IsInt() {
+		return nil, fmt.Errorf("invalid Shelley genesis: unsupported slot length %s", genesis.SlotLength.FloatString(9))
+	}
+	return NewSlotClock(genesis.SystemStart, []EraSummary{{
+		SlotLength:  time.Duration(slotLength.Num().Int64()),
+		EpochLength: uint64(genesis.EpochLength),
+	}})
+}
+
+// systemStartTime converts the LocalStateQuery system start, which is encoded
+// as a year, a 1-based day of the year and picoseconds into that day.
+func systemStartTime(s *localstatequery.SystemStartResult) (time.Time, error) {
+	if s == nil {
+		return time.Time{}, errors.New("missing system start")
+	}
+	if !s.Year.IsInt64() {
+		return time.Time{}, fmt.Errorf("invalid system start year: %s", s.Year.String())
+	}
+	dayOffset, err := picosecondsToDuration(&s.Picoseconds)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid system start time of day: %w", err)
+	}
+	return time.Date(int(s.Year.Int64()), time.January, s.Day, 0, 0, 0, 0, time.UTC).Add(dayOffset), nil
+}
+
+// picosecondsToDuration converts a CBOR-decoded picosecond count, which is
+// a bignum once it no longer fits in 64 bits, to a time.Duration.
+func picosecondsToDuration(v any) (time.Duration, error) {
+	var ps big.Int
+	switch x := v.(type) {
+	case uint64:
+		ps.SetUint64(x)
+	case int64:
+		ps.SetInt64(x)
+	case big.Int:
+		ps.Set(&x)
+	case *big.Int:
+		if x == nil {
+			return 0, errors.New("missing picosecond value")
+		}
+		ps.Set(x)
+	default:
+		return 0, fmt.Errorf("unexpected picosecond value type %T", v)
+	}
+	ns := new(big.Int).Quo(&ps, big.NewInt(1000))
+	if !ns.IsInt64() {
+		return 0, fmt.Errorf("picosecond value out of range: %s", ps.String())
+	}
+	return time.Duration(ns.Int64()), nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/gouroboros/protocol/localstatequery"
+)
+
+// mainnetShelleyStartPicos is the mainnet Shelley hard fork time relative to
+// system start (4492800 Byron slots of 20s), in picoseconds. It does not fit
+// in a uint64, so the node sends it as a CBOR bignum.
+func mainnetShelleyStartPicos() *big.Int {
+	ret, _ := new(big.Int).SetString("89856000000000000000", 10)
+	return ret
+}
+
+// mainnetSlotClock returns a SlotClock with the mainnet Byron and Shelley-onward
+// era parameters, built the same way as from a node query.
+func mainnetSlotClock(t *testing.T) *SlotClock {
+	t.Helper()
+	systemStart := &localstatequery.SystemStartResult{Day: 266}
+	systemStart.Year.SetInt64(2017)
+	// 21:44:51 into the day
+	systemStart.Picoseconds.SetString("78291000000000000", 10)
+
+	history := make([]localstatequery.EraHistoryResult, 2)
+	history[0].Begin.Timespan = uint64(0)
+	history[0].Params.EpochLength = 21600
+	history[0].Params.SlotLength = 20000
+	history[1].Begin.Timespan = *mainnetShelleyStartPicos()
+	history[1].Begin.SlotNo = 4492800
+	history[1].Begin.EpochNo = 208
+	history[1].Params.EpochLength = 432000
+	history[1].Params.SlotLength = 1000
+
+	clock, err := slotClockFromNode(systemStart, history)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return clock
+}
+
+func TestSlotClock_SystemStart(t *testing.T) {
+	t.Parallel()
+	clock := mainnetSlotClock(t)
+	want := time.Date(2017, time.September, 23, 21, 44, 51, 0, time.UTC)
+	if !clock.SystemStart.Equal(want) {
+		t.Errorf("SystemStart: want %s, got %s", want, clock.SystemStart)
+	}
+}
+
+func TestSlotClock_SlotToTime(t *testing.T) {
+	t.Parallel()
+	clock := mainnetSlotClock(t)
+	tests := []struct {
+		name string
+		slot uint64
+		want time.Time
+	}{
+		{
+			name: "byron slot",
+			slot: 21600,
+			want: time.Date(2017, time.September, 28, 21, 44, 51, 0, time.UTC),
+		},
+		{
+			name: "shelley hard fork",
+			slot: 4492800,
+			want: time.Date(2020, time.July, 29, 21, 44, 51, 0, time.UTC),
+		},
+		{
+			// Mainnet Shelley-onward slots map to POSIX time 1591566291 + slot.
+			name: "conway slot",
+			slot: 140000000,
+			want: time.Unix(1591566291+140000000, 0).UTC(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := clock.SlotToTime(tt.slot)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+			slot, err := clock.TimeToSlot(got)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if slot != tt.slot {
+				t.Errorf("round trip: want slot %d, got %d", tt.slot, slot)
+			}
+		})
+	}
+}
+
+func TestSlotClock_Epochs(t *testing.T) {
+	t.Parallel()
+	clock := mainnetSlotClock(t)
+	tests := []struct {
+		slot  uint64
+		epoch uint64
+	}{
+		{slot: 0, epoch: 0},
+		{slot: 21599, epoch: 0},
+		{slot: 4492799, epoch: 207},
+		{slot: 4492800, epoch: 208},
+		{slot: 4492800 + 432000*300 + 5, epoch: 508},
+	}
+	for _, tt := range tests {
+		epoch, err := clock.SlotToEpoch(tt.slot)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if epoch != tt.epoch {
+			t.Errorf("slot %d: want epoch %d, got %d", tt.slot, tt.epoch, epoch)
+		}
+	}
+	startSlot, err := clock.EpochStartSlot(508)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := uint64(4492800 + 432000*300); startSlot != want {
+		t.Errorf("EpochStartSlot(508): want %d, got %d", want, startSlot)
+	}
+}
+
+func TestSlotClock_TimeToSlot_MidSlot(t *testing.T) {
+	t.Parallel()
+	clock := mainnetSlotClock(t)
+	// Halfway through a 20s Byron slot still belongs to that slot.
+	slot, err := clock.TimeToSlot(clock.SystemStart.Add(30 * time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if slot != 1 {
+		t.Errorf("want slot 1, got %d", slot)
+	}
+}
+
+func TestSlotClock_TimeBeforeSystemStart(t *testing.T) {
+	t.Parallel()
+	clock := mainnetSlotClock(t)
+	if _, err := clock.TimeToSlot(clock.SystemStart.Add(-time.Second)); err == nil {
+		t.Error("expected error for time before system start, got nil")
+	}
+}
+
+func TestNewSlotClock_Invalid(t *testing.T) {
+	t.Parallel()
+	if _, err := NewSlotClock(time.Now(), nil); err == nil {
+		t.Error("expected error for empty era list, got nil")
+	}
+	if _, err := NewSlotClock(time.Now(), []EraSummary{{}}); err == nil {
+		t.Error("expected error for zero slot length, got nil")
+	}
+}
+
+func TestPicosecondsToDuration(t *testing.T) {
+	t.Parallel()
+	got, err := picosecondsToDuration(*mainnetShelleyStartPicos())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 89856000 * time.Second; got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+	if _, err := picosecondsToDuration("bogus"); err == nil {
+		t.Error("expected error for unsupported type, got nil")
+	}
+}
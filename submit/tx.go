@@ -18,6 +18,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	ouroboros "github.com/blinklabs-io/gouroboros"
@@ -33,18 +36,68 @@ type Config struct {
 	NodePort     uint
 	SocketPath   string
 	Timeout      uint
+	// Clock, when set, is used to convert slots to wall-clock time instead of
+	// the era history queried from the node
+	Clock *SlotClock
+	// CurrentEra, when set, returns the current era of the node, which
+	// SubmitTx checks the transaction against with CheckTxEra. It may return a
+	// cached era, and is called again with refresh set to query the node
+	// before a transaction is refused. The check is skipped if it returns an
+	// error.
+	CurrentEra func(refresh bool) (ledger.Era, error)
+}
+
+// Node calls reported to the NodeCallObserver
+const (
+	// NodeCallDial is a DialNode call, including the handshake.
+	NodeCallDial = "dial"
+	// NodeCallSubmitTx is the LocalTxSubmission round trip of SubmitTx.
+	NodeCallSubmitTx = "submit_tx"
+)
+
+// NodeCallObserver is called after each call to a node with the kind of call,
+// the endpoint of the node as returned by NodeEndpoint, the time the call
+// took and its error, if any.
+type NodeCallObserver func(call, endpoint string, duration time.Duration, err error)
+
+var nodeCallObserver atomic.Pointer[NodeCallObserver]
+
+// ObserveNodeCalls sets fn to be called after each node dial and transaction
+// submission, such as to record their latency. A nil fn disables it.
+func ObserveNodeCalls(fn NodeCallObserver) {
+	nodeCallObserver.Store(&fn)
+}
+
+func observeNodeCall(call, endpoint string, start time.Time, err error) {
+	if fn := nodeCallObserver.Load(); fn != nil && *fn != nil {
+		(*fn)(call, endpoint, time.Since(start), err)
+	}
+}
+
+// NodeEndpoint returns the endpoint DialNode connects to, as
+// "tcp://host:port" when a node address and port are set, or as
+// "unix://path" for the UNIX socket.
+func NodeEndpoint(nodeAddress string, nodePort uint, socketPath string) string {
+	if nodeAddress != "" && nodePort > 0 {
+		return "tcp://" + net.JoinHostPort(nodeAddress, strconv.FormatUint(uint64(nodePort), 10))
+	}
+	return "unix://" + socketPath
 }
 
 // DialNode creates and dials an Ouroboros connection to the configured node.
 // Callers are responsible for closing the returned connection.
 // Additional protocol options (e.g. WithLocalTxMonitorConfig) can be supplied
 // via opts and are applied after the base options.
-func DialNode(networkMagic uint32, nodeAddress string, nodePort uint, socketPath string, opts ...ouroboros.ConnectionOptionFunc) (*ouroboros.Connection, error) {
+func DialNode(networkMagic uint32, nodeAddress string, nodePort uint, socketPath string, opts ...ouroboros.ConnectionOptionFunc) (oConn *ouroboros.Connection, err error) {
+	start := time.Now()
+	defer func() {
+		observeNodeCall(NodeCallDial, NodeEndpoint(nodeAddress, nodePort, socketPath), start, err)
+	}()
 	baseOpts := []ouroboros.ConnectionOptionFunc{
 		ouroboros.WithNetworkMagic(networkMagic),
 		ouroboros.WithNodeToNode(false),
 	}
-	oConn, err := ouroboros.NewConnection(append(baseOpts, opts...)...)
+	oConn, err = ouroboros.NewConnection(append(baseOpts, opts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failure creating ouroboros connection: %w", err)
 	}
@@ -60,6 +113,24 @@ func DialNode(networkMagic uint32, nodeAddress string, nodePort uint, socketPath
 	return oConn, nil
 }
 
+// checkTxEra checks txType against the era returned by cfg.CurrentEra. A
+// cached era is stale after a hard fork, so the era is refreshed before the
+// transaction is refused.
+func (cfg *Config) checkTxEra(txRawBytes []byte, txType uint) (uint, error) {
+	nodeEra, err := cfg.CurrentEra(false)
+	if err != nil {
+		return txType, nil
+	}
+	if checkedType, err := CheckTxEra(txRawBytes, txType, nodeEra); err == nil {
+		return checkedType, nil
+	}
+	nodeEra, err = cfg.CurrentEra(true)
+	if err != nil {
+		return txType, nil
+	}
+	return CheckTxEra(txRawBytes, txType, nodeEra)
+}
+
 func SubmitTx(cfg *Config, txRawBytes []byte) (string, error) {
 	// Fail fast if timeout is too large
 	if cfg.Timeout > math.MaxInt64 {
@@ -73,6 +144,12 @@ func SubmitTx(cfg *Config, txRawBytes []byte) (string, error) {
 			err,
 		)
 	}
+	if cfg.CurrentEra != nil {
+		txType, err = cfg.checkTxEra(txRawBytes, txType)
+		if err != nil {
+			return "", err
+		}
+	}
 	tx, err := ledger.NewTransactionFromCbor(txType, txRawBytes)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse transaction CBOR: %w", err)
@@ -103,8 +180,11 @@ func SubmitTx(cfg *Config, txRawBytes []byte) (string, error) {
 	defer oConn.Close()
 
 	// Submit the transaction
+	submitStart := time.Now()
 	// #nosec G115
-	if err := oConn.LocalTxSubmission().Client.SubmitTx(uint16(txType), txRawBytes); err != nil {
+	err = oConn.LocalTxSubmission().Client.SubmitTx(uint16(txType), txRawBytes)
+	observeNodeCall(NodeCallSubmitTx, NodeEndpoint(cfg.NodeAddress, cfg.NodePort, cfg.SocketPath), submitStart, err)
+	if err != nil {
 		return "", fmt.Errorf("transaction rejected: %w", err)
 	}
 	return tx.Hash().String(), nil
@@ -0,0 +1,45 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDialNodeObserved(t *testing.T) {
+	type nodeCall struct {
+		call     string
+		endpoint string
+		err      error
+	}
+	var calls []nodeCall
+	ObserveNodeCalls(func(call, endpoint string, _ time.Duration, err error) {
+		calls = append(calls, nodeCall{call, endpoint, err})
+	})
+	t.Cleanup(func() { ObserveNodeCalls(nil) })
+
+	socketPath := filepath.Join(t.TempDir(), "node.socket")
+	if _, err := DialNode(764824073, "", 0, socketPath); err == nil {
+		t.Fatal("expected error dialing missing socket")
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 observed call, got %d", len(calls))
+	}
+	if calls[0].call != NodeCallDial || calls[0].endpoint != "unix://"+socketPath || calls[0].err == nil {
+		t.Errorf("unexpected observed call %+v", calls[0])
+	}
+}
@@ -0,0 +1,501 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// TxHashReport holds the hash of a transaction, whether its parts are
+// encoded as canonical CBOR, and whether the hashes declared in its body
+// match the contents of the transaction.
+type TxHashReport struct {
+	// TxHash is the hash of the original body bytes, as the node computes it.
+	TxHash    string         `json:"txHash"`
+	Body      CanonicalCheck `json:"body"`
+	Witnesses CanonicalCheck `json:"witnesses"`
+	// AuxData is nil for a transaction without auxiliary data.
+	AuxData *CanonicalCheck `json:"auxData,omitempty"`
+	// AuxDataHash is nil when the transaction has neither auxiliary data nor
+	// an auxiliary data hash.
+	AuxDataHash *HashCheck `json:"auxDataHash,omitempty"`
+	// ScriptDataHash is nil when the transaction has neither redeemers,
+	// datums nor a script data hash.
+	ScriptDataHash *HashCheck `json:"scriptDataHash,omitempty"`
+}
+
+// CanonicalCheck reports whether a part of a transaction is encoded as
+// canonical CBOR, as defined in RFC 7049 section 3.9: the shortest form of
+// each integer and length, no indefinite-length items, and map keys sorted
+// by length then bytewise.
+type CanonicalCheck struct {
+	Canonical bool `json:"canonical"`
+	// Issue describes the first non-canonical item found.
+	Issue string `json:"issue,omitempty"`
+}
+
+// HashCheck compares a hash declared in a transaction body with the hash
+// computed from the contents of the transaction.
+type HashCheck struct {
+	Declared string `json:"declared,omitempty"`
+	Computed string `json:"computed,omitempty"`
+	Match    bool   `json:"match"`
+	// Languages are the Plutus languages whose cost models are covered by a
+	// computed script data hash.
+	Languages []string `json:"languages,omitempty"`
+	// Error is set when the hash could not be computed, such as when the
+	// protocol parameters are not available.
+	Error string `json:"error,omitempty"`
+}
+
+// Mismatches describes each hash declared in the transaction body that does
+// not match the contents of the transaction. Hashes that could not be
+// computed are not reported.
+func (r *TxHashReport) Mismatches() []string {
+	var ret []string
+	if c := r.AuxDataHash; c != nil && !c.Match {
+		switch {
+		case c.Computed == "":
+			ret = append(ret, fmt.Sprintf(
+				"body declares auxiliary data hash %s, but the transaction has no auxiliary data",
+				c.Declared,
+			))
+		case c.Declared == "":
+			ret = append(ret, fmt.Sprintf(
+				"body declares no auxiliary data hash, but the transaction has auxiliary data hashing to %s",
+				c.Computed,
+			))
+		default:
+			ret = append(ret, fmt.Sprintf(
+				"auxiliary data hash mismatch: body declares %s, auxiliary data hashes to %s",
+				c.Declared,
+				c.Computed,
+			))
+		}
+	}
+	if c := r.ScriptDataHash; c != nil && !c.Match && c.Error == "" {
+		switch {
+		case c.Computed == "":
+			ret = append(ret, fmt.Sprintf(
+				"body declares script data hash %s, but the transaction has no redeemers or datums",
+				c.Declared,
+			))
+		case c.Declared == "":
+			ret = append(ret, fmt.Sprintf(
+				"body declares no script data hash, but the transaction has redeemers or datums: computed %s from the redeemers, datums%s",
+				c.Computed,
+				c.languagesSuffix(),
+			))
+		default:
+			ret = append(ret, fmt.Sprintf(
+				"script data hash mismatch: body declares %s, computed %s from the redeemers, datums%s",
+				c.Declared,
+				c.Computed,
+				c.languagesSuffix(),
+			))
+		}
+	}
+	return ret
+}
+
+// languagesSuffix names the cost models covered by a script data hash.
+func (c *HashCheck) languagesSuffix() string {
+	if len(c.Languages) == 0 {
+		return " and no cost models"
+	}
+	return " and the cost models of " + strings.Join(c.Languages, ", ")
+}
+
+// HashTx computes the hash of a transaction from its original body bytes,
+// checks whether its body, witness set and auxiliary data are canonical CBOR,
+// and checks the auxiliary data hash and script data hash declared in the
+// body. The script data hash covers the cost models of the Plutus languages
+// used, so pparams is needed to check it, and resolve is used to find the
+// reference scripts of the spent and reference inputs. resolve may be nil, in
+// which case only the scripts in the witness set are taken into account.
+func HashTx(txRawBytes []byte, pparams *ProtocolParams, resolve UTxOResolver) (*TxHashReport, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := txParts(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	ret := &TxHashReport{
+		TxHash:    lcommon.Blake2b256Hash(parts.body).String(),
+		Body:      checkCanonical(parts.body),
+		Witnesses: checkCanonical(parts.witnesses),
+	}
+	if parts.auxData != nil {
+		auxData := checkCanonical(parts.auxData)
+		ret.AuxData = &auxData
+	}
+	ret.AuxDataHash = checkAuxDataHash(tx, parts.auxData)
+	ret.ScriptDataHash = checkScriptDataHash(tx, parts.witnesses, pparams, resolve)
+	return ret, nil
+}
+
+// rawTxParts holds the original bytes of the parts of a transaction.
+type rawTxParts struct {
+	body      []byte
+	witnesses []byte
+	// auxData is nil for a transaction without auxiliary data.
+	auxData []byte
+}
+
+// txParts splits a [body, witnesses, (is_valid,) auxiliary_data] transaction
+// into the original bytes of its parts.
+func txParts(txRawBytes []byte) (*rawTxParts, error) {
+	var parts []cbor.RawMessage
+	if _, err := cbor.Decode(txRawBytes, &parts); err != nil {
+		return nil, err
+	}
+	ret := &rawTxParts{}
+	var auxData []byte
+	switch len(parts) {
+	case 3:
+		auxData = parts[2]
+	case 4:
+		auxData = parts[3]
+	default:
+		return nil, fmt.Errorf("unsupported transaction with %d parts", len(parts))
+	}
+	ret.body = parts[0]
+	ret.witnesses = parts[1]
+	// Missing auxiliary data is encoded as null
+	if !bytes.Equal(auxData, []byte{0xf6}) {
+		ret.auxData = auxData
+	}
+	return ret, nil
+}
+
+// checkAuxDataHash compares the auxiliary data hash in the body of tx with
+// the hash of the original auxiliary data bytes.
+func checkAuxDataHash(tx ledger.Transaction, auxData []byte) *HashCheck {
+	declared := tx.AuxDataHash()
+	if declared == nil && auxData == nil {
+		return nil
+	}
+	ret := &HashCheck{}
+	if declared != nil {
+		ret.Declared = declared.String()
+	}
+	if auxData != nil {
+		ret.Computed = lcommon.Blake2b256Hash(auxData).String()
+	}
+	ret.Match = ret.Declared == ret.Computed
+	return ret
+}
+
+// checkScriptDataHash compares the script data hash in the body of tx with
+// the hash of its redeemers, datums and the language views of the cost
+// models of the Plutus languages it uses, computed from the original witness
+// set bytes.
+func checkScriptDataHash(
+	tx ledger.Transaction,
+	witnessesRaw []byte,
+	pparams *ProtocolParams,
+	resolve UTxOResolver,
+) *HashCheck {
+	var witnesses map[uint]cbor.RawMessage
+	if _, err := cbor.Decode(witnessesRaw, &witnesses); err != nil {
+		return &HashCheck{Error: fmt.Sprintf("failed to decode witness set: %s", err)}
+	}
+	redeemers, hasRedeemers := witnesses[witnessRedeemersKey]
+	datums, hasDatums := witnesses[witnessPlutusDataKey]
+	declared := tx.ScriptDataHash()
+	if !hasRedeemers && !hasDatums && declared == nil {
+		return nil
+	}
+	ret := &HashCheck{}
+	if declared != nil {
+		ret.Declared = declared.String()
+	}
+	if !hasRedeemers && !hasDatums {
+		// A script data hash without redeemers or datums is extraneous
+		return ret
+	}
+	if pparams == nil {
+		ret.Error = "protocol parameters are not available"
+		return ret
+	}
+	versions, err := plutusVersions(tx, resolve)
+	if err != nil {
+		ret.Error = err.Error()
+		return ret
+	}
+	costModels := make(map[uint][]int64)
+	for _, version := range slices.Sorted(maps.Keys(versions)) {
+		language := fmt.Sprintf("PlutusV%d", version+1)
+		model, ok := pparams.CostModels[language]
+		if !ok {
+			ret.Error = fmt.Sprintf("no cost model for %s in the protocol parameters", language)
+			return ret
+		}
+		costModels[version] = model
+		ret.Languages = append(ret.Languages, language)
+	}
+	langViews, err := lcommon.EncodeLangViews(versions, costModels)
+	if err != nil {
+		ret.Error = err.Error()
+		return ret
+	}
+	if !hasRedeemers {
+		// Redeemers are a map from Conway onwards, and an array before
+		redeemers = []byte{0x80}
+		if tx.Type() >= ledger.TxTypeConway {
+			redeemers = []byte{0xa0}
+		}
+	}
+	hashInput := bytes.Clone(redeemers)
+	if hasDatums {
+		hashInput = append(hashInput, datums...)
+	}
+	hashInput = append(hashInput, langViews...)
+	ret.Computed = lcommon.Blake2b256Hash(hashInput).String()
+	ret.Match = ret.Declared == ret.Computed
+	return ret
+}
+
+const (
+	witnessPlutusDataKey = 4
+	witnessRedeemersKey  = 5
+)
+
+// plutusVersions returns the ledger language IDs of the Plutus scripts tx
+// needs to run, as the ledger computes them for the script data hash. The
+// scripts are looked up in the witness set and, if resolve is not nil, in
+// the reference scripts of the spent and reference inputs. Reference scripts
+// the transaction does not run are not counted. An input missing from the
+// UTxO set, such as an output of a transaction still in the mempool, is an
+// error, as the languages of its reference script are not known. When
+// resolve is nil, the scripts needed to spend inputs are not known, so every
+// Plutus script in the witness set is counted, as it would be for a
+// transaction the ledger accepts.
+func plutusVersions(tx ledger.Transaction, resolve UTxOResolver) (map[uint]struct{}, error) {
+	scripts := make(map[lcommon.ScriptHash]lcommon.Script)
+	for _, script := range witnessScripts(tx.Witnesses()) {
+		scripts[script.Hash()] = script
+	}
+	ret := make(map[uint]struct{})
+	if resolve == nil {
+		for _, script := range scripts {
+			if version, ok := lcommon.PlutusScriptVersion(script); ok {
+				ret[version] = struct{}{}
+			}
+		}
+		return ret, nil
+	}
+	var utxos map[string]ledger.TransactionOutput
+	if inputs := slices.Concat(tx.Inputs(), tx.ReferenceInputs()); len(inputs) > 0 {
+		var err error
+		utxos, err = resolve(inputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve inputs for reference scripts: %w", err)
+		}
+		var missing []string
+		for _, input := range inputs {
+			output, ok := utxos[input.String()]
+			if !ok || output == nil {
+				missing = append(missing, input.String())
+				continue
+			}
+			if ref := output.ScriptRef(); ref != nil {
+				scripts[ref.Hash()] = ref
+			}
+		}
+		if len(missing) > 0 {
+			return nil, &UnresolvedInputsError{Inputs: missing}
+		}
+	}
+	for hash := range scriptsNeeded(tx, utxos) {
+		if script, ok := scripts[hash]; ok {
+			if version, ok := lcommon.PlutusScriptVersion(script); ok {
+				ret[version] = struct{}{}
+			}
+		}
+	}
+	return ret, nil
+}
+
+// scriptsNeeded returns the hashes of the scripts tx needs to run: those of
+// the script addresses of its spent inputs resolved in utxos, its minting
+// policies, the script credentials of its withdrawals, certificates and
+// voters, and the guardrails script of its proposals. Spent inputs missing
+// from utxos are skipped.
+func scriptsNeeded(tx ledger.Transaction, utxos map[string]ledger.TransactionOutput) map[lcommon.ScriptHash]struct{} {
+	ret := make(map[lcommon.ScriptHash]struct{})
+	for _, input := range tx.Inputs() {
+		output, ok := utxos[input.String()]
+		if !ok || output == nil {
+			continue
+		}
+		addr := output.Address()
+		if payload, ok := addr.PayloadPayload().(lcommon.AddressPayloadScriptHash); ok {
+			ret[lcommon.ScriptHash(payload.Hash)] = struct{}{}
+		}
+	}
+	if mint := tx.AssetMint(); mint != nil {
+		for _, policyId := range mint.Policies() {
+			ret[lcommon.ScriptHash(policyId)] = struct{}{}
+		}
+	}
+	for addr := range tx.Withdrawals() {
+		if addr == nil {
+			continue
+		}
+		if payload, ok := addr.StakingPayload().(lcommon.AddressPayloadScriptHash); ok {
+			ret[lcommon.ScriptHash(payload.Hash)] = struct{}{}
+		}
+	}
+	for _, cert := range tx.Certificates() {
+		for _, cred := range certWitnessCredentials(cert) {
+			if cred.CredType == lcommon.CredentialTypeScriptHash {
+				ret[lcommon.ScriptHash(cred.Credential)] = struct{}{}
+			}
+		}
+	}
+	for voter := range tx.VotingProcedures() {
+		if voter == nil {
+			continue
+		}
+		switch voter.Type {
+		case lcommon.VoterTypeConstitutionalCommitteeHotScriptHash,
+			lcommon.VoterTypeDRepScriptHash:
+			ret[lcommon.ScriptHash(lcommon.NewBlake2b224(voter.Hash[:]))] = struct{}{}
+		}
+	}
+	for _, proposal := range tx.ProposalProcedures() {
+		if action, ok := proposal.GovAction().(lcommon.GovActionWithPolicy); ok {
+			if policy := action.GetPolicyHash(); len(policy) == lcommon.Blake2b224Size {
+				ret[lcommon.ScriptHash(lcommon.NewBlake2b224(policy))] = struct{}{}
+			}
+		}
+	}
+	return ret
+}
+
+// checkCanonical checks whether data is a single canonical CBOR item.
+func checkCanonical(data []byte) CanonicalCheck {
+	end, err := checkCanonicalItem(data, 0)
+	if err == nil && end != len(data) {
+		err = fmt.Errorf("trailing bytes at offset %d", end)
+	}
+	if err != nil {
+		return CanonicalCheck{Issue: err.Error()}
+	}
+	return CanonicalCheck{Canonical: true}
+}
+
+var errCborTruncated = errors.New("truncated CBOR")
+
+// checkCanonicalItem checks the CBOR item at offset of data, and returns the
+// offset of the end of the item.
+func checkCanonicalItem(data []byte, offset int) (int, error) {
+	if offset >= len(data) {
+		return 0, errCborTruncated
+	}
+	major := data[offset] >> 5
+	info := data[offset] & 0x1f
+	start := offset
+	offset++
+	var arg uint64
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		size := 1 << (info - 24)
+		if offset+size > len(data) {
+			return 0, errCborTruncated
+		}
+		switch size {
+		case 1:
+			arg = uint64(data[offset])
+		case 2:
+			arg = uint64(binary.BigEndian.Uint16(data[offset:]))
+		case 4:
+			arg = uint64(binary.BigEndian.Uint32(data[offset:]))
+		case 8:
+			arg = binary.BigEndian.Uint64(data[offset:])
+		}
+		offset += size
+		// Floats are exempt, as their size is part of their value
+		minArg := [...]uint64{24, 1 << 8, 1 << 16, 1 << 32}[info-24]
+		if major != 7 && arg < minArg {
+			return 0, fmt.Errorf("non-minimal integer or length at offset %d", start)
+		}
+	case info == 31:
+		return 0, fmt.Errorf("indefinite-length item at offset %d", start)
+	default:
+		return 0, fmt.Errorf("invalid CBOR at offset %d", start)
+	}
+	switch major {
+	case 2, 3:
+		if arg > uint64(len(data)-offset) {
+			return 0, errCborTruncated
+		}
+		return offset + int(arg), nil
+	case 4:
+		var err error
+		for range arg {
+			if offset, err = checkCanonicalItem(data, offset); err != nil {
+				return 0, err
+			}
+		}
+		return offset, nil
+	case 5:
+		var prevKey []byte
+		for range arg {
+			keyStart := offset
+			keyEnd, err := checkCanonicalItem(data, offset)
+			if err != nil {
+				return 0, err
+			}
+			key := data[keyStart:keyEnd]
+			if prevKey != nil && !canonicalKeyLess(prevKey, key) {
+				return 0, fmt.Errorf("map keys not sorted or duplicated at offset %d", keyStart)
+			}
+			prevKey = key
+			if offset, err = checkCanonicalItem(data, keyEnd); err != nil {
+				return 0, err
+			}
+		}
+		return offset, nil
+	case 6:
+		return checkCanonicalItem(data, offset)
+	default:
+		return offset, nil
+	}
+}
+
+// canonicalKeyLess reports whether the encoded map key a sorts before b:
+// shorter keys first, then bytewise.
+func canonicalKeyLess(a, b []byte) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return bytes.Compare(a, b) < 0
+}
@@ -0,0 +1,362 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+)
+
+func TestCheckCanonical(t *testing.T) {
+	testCases := []struct {
+		name  string
+		hex   string
+		issue string
+	}{
+		{name: "sorted map", hex: "a201010202"},
+		{name: "shorter key first", hex: "a262616201636161610d"},
+		{name: "tag and bytes", hex: "d9010281581c" + strings.Repeat("00", 28)},
+		{name: "unsorted map", hex: "a202010101", issue: "map keys not sorted or duplicated at offset 3"},
+		{name: "longer key first", hex: "a2636161610d62616201", issue: "map keys not sorted or duplicated at offset 6"},
+		{name: "duplicate key", hex: "a201010102", issue: "map keys not sorted or duplicated at offset 3"},
+		{name: "non-minimal integer", hex: "1817", issue: "non-minimal integer or length at offset 0"},
+		{name: "non-minimal length", hex: "8201990001 00", issue: "non-minimal integer or length at offset 2"},
+		{name: "indefinite array", hex: "9f01ff", issue: "indefinite-length item at offset 0"},
+		{name: "truncated", hex: "8201", issue: "truncated CBOR"},
+		{name: "trailing bytes", hex: "0101", issue: "trailing bytes at offset 1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := checkCanonical(mustDecodeHex(t, strings.ReplaceAll(tc.hex, " ", "")))
+			want := CanonicalCheck{Canonical: tc.issue == "", Issue: tc.issue}
+			if got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		})
+	}
+}
+
+func TestHashTx(t *testing.T) {
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	report, err := HashTx(txBytes, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tx, err := decodeTx(txBytes)
+	if err != nil {
+		t.Fatalf("decode tx: %s", err)
+	}
+	want := TxHashReport{
+		TxHash:    tx.Hash().String(),
+		Body:      CanonicalCheck{Canonical: true},
+		Witnesses: CanonicalCheck{Canonical: true},
+	}
+	if *report != want {
+		t.Errorf("expected %+v, got %+v", want, *report)
+	}
+}
+
+func TestHashTx_AuxDataHash(t *testing.T) {
+	auxData := mustEncode(t, map[uint]any{674: "hello"})
+	auxDataHash := lcommon.Blake2b256Hash(auxData)
+	testCases := []struct {
+		name     string
+		declared []byte
+		auxData  bool
+		want     HashCheck
+	}{
+		{
+			name:     "match",
+			declared: auxDataHash.Bytes(),
+			auxData:  true,
+			want:     HashCheck{Declared: auxDataHash.String(), Computed: auxDataHash.String(), Match: true},
+		},
+		{
+			name:     "mismatch",
+			declared: bytes.Repeat([]byte{0xff}, 32),
+			auxData:  true,
+			want:     HashCheck{Declared: strings.Repeat("ff", 32), Computed: auxDataHash.String()},
+		},
+		{
+			name:    "missing hash",
+			auxData: true,
+			want:    HashCheck{Computed: auxDataHash.String()},
+		},
+		{
+			name:     "missing auxiliary data",
+			declared: auxDataHash.Bytes(),
+			want:     HashCheck{Declared: auxDataHash.String()},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := buildMinimalConwayBody()
+			if tc.declared != nil {
+				body[7] = tc.declared
+			}
+			var aux any
+			if tc.auxData {
+				aux = gocbor.RawMessage(auxData)
+			}
+			txBytes := mustEncode(t, []any{body, map[uint]any{}, true, aux})
+			report, err := HashTx(txBytes, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if report.AuxDataHash == nil || !hashCheckEqual(*report.AuxDataHash, tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, report.AuxDataHash)
+			}
+			if tc.auxData && (report.AuxData == nil || !report.AuxData.Canonical) {
+				t.Errorf("expected canonical auxiliary data, got %+v", report.AuxData)
+			}
+		})
+	}
+}
+
+func TestHashTx_ScriptDataHash(t *testing.T) {
+	redeemers := mustEncode(t, [][]any{{uint8(lcommon.RedeemerTagSpend), uint32(0), int64(0), []int64{1_000, 1_000}}})
+	datums := mustEncode(t, []any{int64(42)})
+	v3Script := compilePlutusV3(t, traceSucceedsScript)
+	pparams := &ProtocolParams{CostModels: map[string][]int64{
+		"PlutusV1": {1, 2, 3},
+		"PlutusV3": {4, 5, 6},
+	}}
+	// The language view of PlutusV1 is keyed by the serialized language ID,
+	// with the cost model serialized as an indefinite-length list
+	v1LangViews := "a14100459f010203ff"
+	v3LangViews := "a10283040506"
+	hash := func(parts ...string) string {
+		var data []byte
+		for _, part := range parts {
+			data = append(data, mustDecodeHex(t, part)...)
+		}
+		return lcommon.Blake2b256Hash(data).String()
+	}
+	refScriptOutput := babbage.BabbageTransactionOutput{
+		OutputAddress:  scriptAddress(t, v3Script),
+		OutputAmount:   mary.MaryTransactionOutputValue{Amount: 5_000_000},
+		TxOutScriptRef: &lcommon.ScriptRef{Type: lcommon.ScriptRefTypePlutusV3, Script: v3Script},
+	}
+	// A reference script the transaction does not run, such as one deployed
+	// at a key address and spent to move it
+	unusedRefScriptOutput := babbage.BabbageTransactionOutput{
+		OutputAmount:   mary.MaryTransactionOutputValue{Amount: 5_000_000},
+		TxOutScriptRef: &lcommon.ScriptRef{Type: lcommon.ScriptRefTypePlutusV3, Script: v3Script},
+	}
+
+	testCases := []struct {
+		name      string
+		witnesses map[uint]any
+		refInput  bool
+		pparams   *ProtocolParams
+		resolve   UTxOResolver
+		want      string
+		languages []string
+		wantErr   string
+	}{
+		{
+			name: "witness script",
+			witnesses: map[uint]any{
+				3: []any{mustDecodeHex(t, "510101003222253330044a229309b2b2b9a1")},
+				4: gocbor.RawMessage(datums),
+				5: gocbor.RawMessage(redeemers),
+			},
+			pparams:   pparams,
+			want:      hash(hex.EncodeToString(redeemers), hex.EncodeToString(datums), v1LangViews),
+			languages: []string{"PlutusV1"},
+		},
+		{
+			name:      "reference script",
+			witnesses: map[uint]any{5: gocbor.RawMessage(redeemers)},
+			pparams:   pparams,
+			resolve: fixtureResolver(map[string]ledger.TransactionOutput{
+				spentInput: refScriptOutput,
+			}),
+			want:      hash(hex.EncodeToString(redeemers), v3LangViews),
+			languages: []string{"PlutusV3"},
+		},
+		{
+			name:      "unused reference scripts",
+			witnesses: map[uint]any{5: gocbor.RawMessage(redeemers)},
+			refInput:  true,
+			pparams:   pparams,
+			resolve: fixtureResolver(map[string]ledger.TransactionOutput{
+				spentInput: unusedRefScriptOutput,
+				refInput:   unusedRefScriptOutput,
+			}),
+			want: hash(hex.EncodeToString(redeemers), "a0"),
+		},
+		{
+			name:      "unresolved reference input",
+			witnesses: map[uint]any{5: gocbor.RawMessage(redeemers)},
+			refInput:  true,
+			pparams:   pparams,
+			resolve: fixtureResolver(map[string]ledger.TransactionOutput{
+				spentInput: refScriptOutput,
+			}),
+			wantErr: "inputs not found in the UTxO set: " + refInput,
+		},
+		{
+			name:      "datums only",
+			witnesses: map[uint]any{4: gocbor.RawMessage(datums)},
+			pparams:   pparams,
+			want:      hash("a0", hex.EncodeToString(datums), "a0"),
+		},
+		{
+			name:      "no protocol parameters",
+			witnesses: map[uint]any{5: gocbor.RawMessage(redeemers)},
+			wantErr:   "protocol parameters are not available",
+		},
+		{
+			name:      "no cost model",
+			witnesses: map[uint]any{6: []any{mustDecodeHex(t, "510101003222253330044a229309b2b2b9a1")}, 5: gocbor.RawMessage(redeemers)},
+			pparams:   pparams,
+			wantErr:   "no cost model for PlutusV2 in the protocol parameters",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, declared := range []string{tc.want, strings.Repeat("ff", 32)} {
+				body := buildMinimalConwayBody()
+				if tc.refInput {
+					body[18] = [][]any{{make([]byte, 32), uint32(1)}}
+				}
+				if declared != "" {
+					body[11] = mustDecodeHex(t, declared)
+				}
+				report, err := HashTx(buildConwayTx(t, body, tc.witnesses), tc.pparams, tc.resolve)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				got := report.ScriptDataHash
+				if got == nil {
+					t.Fatal("expected a script data hash check")
+				}
+				if tc.wantErr != "" {
+					if got.Error != tc.wantErr || got.Match {
+						t.Errorf("expected error %q, got %+v", tc.wantErr, got)
+					}
+					continue
+				}
+				want := HashCheck{Declared: declared, Computed: tc.want, Match: declared == tc.want, Languages: tc.languages}
+				if !hashCheckEqual(*got, want) {
+					t.Errorf("expected %+v, got %+v", want, *got)
+				}
+			}
+		})
+	}
+}
+
+func TestHashTx_ExtraneousScriptDataHash(t *testing.T) {
+	body := buildMinimalConwayBody()
+	body[11] = bytes.Repeat([]byte{0xff}, 32)
+	report, err := HashTx(buildConwayTx(t, body, map[uint]any{}), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := HashCheck{Declared: strings.Repeat("ff", 32)}
+	if report.ScriptDataHash == nil || !hashCheckEqual(*report.ScriptDataHash, want) {
+		t.Errorf("expected %+v, got %+v", want, report.ScriptDataHash)
+	}
+}
+
+func TestTxHashReport_Mismatches(t *testing.T) {
+	declared := strings.Repeat("aa", 32)
+	computed := strings.Repeat("bb", 32)
+	testCases := []struct {
+		name   string
+		report TxHashReport
+		want   []string
+	}{
+		{
+			name: "matching hashes",
+			report: TxHashReport{
+				AuxDataHash:    &HashCheck{Declared: declared, Computed: declared, Match: true},
+				ScriptDataHash: &HashCheck{Declared: computed, Computed: computed, Match: true},
+			},
+		},
+		{
+			name: "auxiliary data hash mismatch",
+			report: TxHashReport{
+				AuxDataHash: &HashCheck{Declared: declared, Computed: computed},
+			},
+			want: []string{"auxiliary data hash mismatch: body declares " + declared + ", auxiliary data hashes to " + computed},
+		},
+		{
+			name: "missing auxiliary data",
+			report: TxHashReport{
+				AuxDataHash: &HashCheck{Declared: declared},
+			},
+			want: []string{"body declares auxiliary data hash " + declared + ", but the transaction has no auxiliary data"},
+		},
+		{
+			name: "script data hash mismatch",
+			report: TxHashReport{
+				ScriptDataHash: &HashCheck{Declared: declared, Computed: computed, Languages: []string{"PlutusV1", "PlutusV3"}},
+			},
+			want: []string{
+				"script data hash mismatch: body declares " + declared + ", computed " + computed +
+					" from the redeemers, datums and the cost models of PlutusV1, PlutusV3",
+			},
+		},
+		{
+			name: "missing script data hash",
+			report: TxHashReport{
+				ScriptDataHash: &HashCheck{Computed: computed},
+			},
+			want: []string{
+				"body declares no script data hash, but the transaction has redeemers or datums: computed " +
+					computed + " from the redeemers, datums and no cost models",
+			},
+		},
+		{
+			name: "extraneous script data hash",
+			report: TxHashReport{
+				ScriptDataHash: &HashCheck{Declared: declared},
+			},
+			want: []string{"body declares script data hash " + declared + ", but the transaction has no redeemers or datums"},
+		},
+		{
+			name: "script data hash not computed",
+			report: TxHashReport{
+				ScriptDataHash: &HashCheck{Declared: declared, Error: "protocol parameters are not available"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.report.Mismatches(); !slices.Equal(got, tc.want) {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func hashCheckEqual(a, b HashCheck) bool {
+	return a.Declared == b.Declared &&
+		a.Computed == b.Computed &&
+		a.Match == b.Match &&
+		a.Error == b.Error &&
+		slices.Equal(a.Languages, b.Languages)
+}
@@ -15,22 +15,178 @@
 package submit
 
 import (
+	"cmp"
+	"encoding/hex"
+	"maps"
+	"math/big"
+	"slices"
+
 	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 )
 
 // TxInfo holds content signals extracted from raw transaction CBOR.
 type TxInfo struct {
+	// Hash is the transaction ID, hex-encoded.
+	Hash string `json:"txHash"`
+
+	// Era is the name of the latest era the transaction decodes in.
+	Era string `json:"era"`
+
+	// Size is the size of the transaction CBOR in bytes.
+	Size int `json:"size"`
+
+	// Fee is the transaction fee in lovelace.
+	Fee uint64 `json:"fee"`
+
+	InputCount          int `json:"inputCount"`
+	OutputCount         int `json:"outputCount"`
+	ReferenceInputCount int `json:"referenceInputCount"`
+
+	// OutputValue is the total value of the transaction outputs, lovelace
+	// first, then native assets sorted by unit.
+	OutputValue []AssetAmount `json:"outputValue"`
+
+	ValidityInterval ValidityInterval `json:"validityInterval"`
+
 	// ScriptType is the highest Plutus version present in the witness set, or
 	// "native" for native scripts only, or "none" if no scripts are present.
 	// Values: "none", "native", "plutus_v1", "plutus_v2", "plutus_v3".
-	ScriptType string
+	ScriptType string `json:"scriptType"`
+
+	// ScriptTypes lists every kind of script present in the witness set, with
+	// the same values as ScriptType other than "none".
+	ScriptTypes []string `json:"scriptTypes"`
+
+	// Redeemers holds the execution units declared by each redeemer, sorted by
+	// tag and index.
+	Redeemers []RedeemerInfo `json:"redeemers"`
+
+	// ScriptHashes are the hashes of the scripts in the witness set, sorted.
+	ScriptHashes []string `json:"scriptHashes"`
+
+	// ScriptCredentials are the script hashes the transaction refers to
+	// without carrying the scripts, such as reference scripts: the script
+	// payment and stake credentials of the output addresses, the script
+	// credentials of withdrawals, certificates and voters, and the minting
+	// policy IDs, sorted.
+	ScriptCredentials []string `json:"scriptCredentials"`
 
 	// HasMinting is true when the transaction mints or burns native tokens.
-	HasMinting bool
+	HasMinting bool `json:"hasMinting"`
+
+	// MintPolicyIds are the policy IDs of the minted or burned assets, sorted.
+	MintPolicyIds []string `json:"mintPolicyIds"`
+
+	// MetadataLabels are the top-level transaction metadata labels, sorted.
+	MetadataLabels []uint64 `json:"metadataLabels"`
+
+	// Addresses are the distinct bech32 addresses of the outputs and the
+	// collateral return, sorted.
+	Addresses []string `json:"addresses"`
 
 	// HasReferenceInputs is true when the transaction includes reference inputs
 	// (Babbage / Conway feature used heavily by DeFi protocols).
-	HasReferenceInputs bool
+	HasReferenceInputs bool `json:"hasReferenceInputs"`
+
+	Governance GovernanceInfo `json:"governance"`
+
+	Staking StakingInfo `json:"staking"`
+
+	// DApp is the dApp the transaction is attributed to, set by
+	// Attribution.Attribute.
+	DApp string `json:"dapp,omitempty"`
+}
+
+// StakingInfo holds the certificates and reward withdrawals of a
+// transaction.
+type StakingInfo struct {
+	// Certificates counts every certificate by category:
+	// "stake_registration" and "stake_deregistration" (legacy and Conway),
+	// "delegation", "pool_registration", "pool_retirement",
+	// "registration_delegation" (combined registration and delegation),
+	// "governance" (DRep, committee and vote delegation certificates) or
+	// "other".
+	Certificates map[string]int `json:"certificates"`
+
+	// Withdrawals is the number of reward withdrawals.
+	Withdrawals int `json:"withdrawals"`
+
+	// WithdrawalAmount is the total of the reward withdrawals in lovelace.
+	WithdrawalAmount uint64 `json:"withdrawalAmount"`
+}
+
+// certificateCategories maps certificate types to the categories counted in
+// StakingInfo. Types missing from it are counted as "other".
+var certificateCategories = map[uint]string{
+	uint(lcommon.CertificateTypeStakeRegistration):               "stake_registration",
+	uint(lcommon.CertificateTypeRegistration):                    "stake_registration",
+	uint(lcommon.CertificateTypeStakeDeregistration):             "stake_deregistration",
+	uint(lcommon.CertificateTypeDeregistration):                  "stake_deregistration",
+	uint(lcommon.CertificateTypeStakeDelegation):                 "delegation",
+	uint(lcommon.CertificateTypeStakeVoteDelegation):             "delegation",
+	uint(lcommon.CertificateTypePoolRegistration):                "pool_registration",
+	uint(lcommon.CertificateTypePoolRetirement):                  "pool_retirement",
+	uint(lcommon.CertificateTypeStakeRegistrationDelegation):     "registration_delegation",
+	uint(lcommon.CertificateTypeVoteRegistrationDelegation):      "registration_delegation",
+	uint(lcommon.CertificateTypeStakeVoteRegistrationDelegation): "registration_delegation",
+	uint(lcommon.CertificateTypeVoteDelegation):                  "governance",
+	uint(lcommon.CertificateTypeAuthCommitteeHot):                "governance",
+	uint(lcommon.CertificateTypeResignCommitteeCold):             "governance",
+	uint(lcommon.CertificateTypeRegistrationDrep):                "governance",
+	uint(lcommon.CertificateTypeDeregistrationDrep):              "governance",
+	uint(lcommon.CertificateTypeUpdateDrep):                      "governance",
+}
+
+// GovernanceInfo holds the Conway governance content of a transaction.
+type GovernanceInfo struct {
+	// Votes counts the voting procedures by voter type:
+	// "constitutional_committee", "drep" or "stake_pool".
+	Votes map[string]int `json:"votes"`
+
+	// Proposals counts the proposal procedures by governance action type,
+	// such as "parameter_change" or "treasury_withdrawal".
+	Proposals map[string]int `json:"proposals"`
+
+	// Certificates counts the DRep registration and update certificates and
+	// the vote delegation certificates by certificate type.
+	Certificates map[string]int `json:"certificates"`
+
+	// Donation is the treasury donation in lovelace.
+	Donation uint64 `json:"donation"`
+}
+
+// governanceCertificateTypes are the certificate types counted in
+// GovernanceInfo.
+var governanceCertificateTypes = map[uint]struct{}{
+	uint(lcommon.CertificateTypeRegistrationDrep):                {},
+	uint(lcommon.CertificateTypeUpdateDrep):                      {},
+	uint(lcommon.CertificateTypeVoteDelegation):                  {},
+	uint(lcommon.CertificateTypeStakeVoteDelegation):             {},
+	uint(lcommon.CertificateTypeVoteRegistrationDelegation):      {},
+	uint(lcommon.CertificateTypeStakeVoteRegistrationDelegation): {},
+}
+
+// AssetAmount is a quantity of lovelace or of a native asset. The unit is
+// "lovelace", or the policy ID followed by the hex-encoded asset name. The
+// quantity is a decimal string, as totals may not fit in a JSON number.
+type AssetAmount struct {
+	Unit     string `json:"unit"`
+	Quantity string `json:"quantity"`
+}
+
+// ValidityInterval holds the slots a transaction is valid between. A zero
+// value means no bound.
+type ValidityInterval struct {
+	InvalidBefore    uint64 `json:"invalidBefore,omitempty"`
+	InvalidHereafter uint64 `json:"invalidHereafter,omitempty"`
+}
+
+// RedeemerInfo holds the execution units declared by a redeemer.
+type RedeemerInfo struct {
+	Tag     lcommon.RedeemerTag `json:"tag"`
+	Index   uint32              `json:"index"`
+	ExUnits lcommon.ExUnits     `json:"exUnits"`
 }
 
 // ParseTxInfo parses raw transaction CBOR and returns content signals without
@@ -47,27 +203,221 @@ func ParseTxInfo(rawBytes []byte) (*TxInfo, error) {
 	}
 
 	info := &TxInfo{
+		Hash: tx.Hash().String(),
+		// #nosec G115 -- transaction types are era IDs
+		Era:                 ledger.GetEraById(uint8(txType)).Name,
+		Size:                len(rawBytes),
+		InputCount:          len(tx.Inputs()),
+		OutputCount:         len(tx.Outputs()),
+		ReferenceInputCount: len(tx.ReferenceInputs()),
+		OutputValue:         outputValue(tx.Outputs()),
+		ValidityInterval: ValidityInterval{
+			InvalidBefore:    tx.ValidityIntervalStart(),
+			InvalidHereafter: tx.TTL(),
+		},
+		ScriptTypes:        []string{},
+		Redeemers:          []RedeemerInfo{},
+		ScriptHashes:       []string{},
+		ScriptCredentials:  txScriptCredentials(tx),
 		HasMinting:         tx.AssetMint() != nil,
+		MintPolicyIds:      []string{},
+		MetadataLabels:     []uint64{},
+		Addresses:          txAddresses(tx),
 		HasReferenceInputs: len(tx.ReferenceInputs()) > 0,
+		Governance:         governanceInfo(tx),
+		Staking:            stakingInfo(tx),
+	}
+	if fee := tx.Fee(); fee != nil && fee.IsUint64() {
+		info.Fee = fee.Uint64()
+	}
+	if mint := tx.AssetMint(); mint != nil {
+		for _, policyId := range mint.Policies() {
+			info.MintPolicyIds = append(info.MintPolicyIds, policyId.String())
+		}
+		slices.Sort(info.MintPolicyIds)
+	}
+	if m, ok := tx.Metadata().(lcommon.MetaMap); ok {
+		for _, pair := range m.Pairs {
+			if label, ok := pair.Key.(lcommon.MetaInt); ok && label.Value != nil && label.Value.IsUint64() {
+				info.MetadataLabels = append(info.MetadataLabels, label.Value.Uint64())
+			}
+		}
+		slices.Sort(info.MetadataLabels)
+	}
+	for _, script := range witnessScripts(tx.Witnesses()) {
+		info.ScriptHashes = append(info.ScriptHashes, script.Hash().String())
 	}
+	slices.Sort(info.ScriptHashes)
+	info.ScriptHashes = slices.Compact(info.ScriptHashes)
 
+	info.ScriptType = "none"
 	w := tx.Witnesses()
 	if w != nil {
-		switch {
-		case len(w.PlutusV3Scripts()) > 0:
-			info.ScriptType = "plutus_v3"
-		case len(w.PlutusV2Scripts()) > 0:
-			info.ScriptType = "plutus_v2"
-		case len(w.PlutusV1Scripts()) > 0:
-			info.ScriptType = "plutus_v1"
-		case len(w.NativeScripts()) > 0:
-			info.ScriptType = "native"
-		default:
-			info.ScriptType = "none"
+		// Ordered from the lowest to the highest, so the last one present is
+		// the ScriptType
+		scripts := []struct {
+			name    string
+			present bool
+		}{
+			{"native", len(w.NativeScripts()) > 0},
+			{"plutus_v1", len(w.PlutusV1Scripts()) > 0},
+			{"plutus_v2", len(w.PlutusV2Scripts()) > 0},
+			{"plutus_v3", len(w.PlutusV3Scripts()) > 0},
+		}
+		for _, s := range scripts {
+			if s.present {
+				info.ScriptTypes = append(info.ScriptTypes, s.name)
+				info.ScriptType = s.name
+			}
+		}
+		if w.Redeemers() != nil {
+			for key, value := range w.Redeemers().Iter() {
+				info.Redeemers = append(info.Redeemers, RedeemerInfo{
+					Tag:     key.Tag,
+					Index:   key.Index,
+					ExUnits: value.ExUnits,
+				})
+			}
+			slices.SortFunc(info.Redeemers, func(a, b RedeemerInfo) int {
+				return cmp.Or(cmp.Compare(a.Tag, b.Tag), cmp.Compare(a.Index, b.Index))
+			})
 		}
-	} else {
-		info.ScriptType = "none"
 	}
 
 	return info, nil
 }
+
+// txScriptCredentials returns the distinct hex script hashes of the script
+// credentials of the outputs and the collateral return of tx, and of the
+// scripts it needs other than to spend its inputs, sorted.
+func txScriptCredentials(tx ledger.Transaction) []string {
+	hashes := scriptsNeeded(tx, nil)
+	outputs := tx.Outputs()
+	if output := tx.CollateralReturn(); output != nil {
+		outputs = append(slices.Clip(outputs), output)
+	}
+	for _, output := range outputs {
+		addr := output.Address()
+		if payload, ok := addr.PayloadPayload().(lcommon.AddressPayloadScriptHash); ok {
+			hashes[lcommon.ScriptHash(payload.Hash)] = struct{}{}
+		}
+		if payload, ok := addr.StakingPayload().(lcommon.AddressPayloadScriptHash); ok {
+			hashes[lcommon.ScriptHash(payload.Hash)] = struct{}{}
+		}
+	}
+	ret := make([]string, 0, len(hashes))
+	for hash := range hashes {
+		ret = append(ret, hash.String())
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// txAddresses returns the distinct bech32 addresses of the outputs and the
+// collateral return of tx, sorted.
+func txAddresses(tx ledger.Transaction) []string {
+	outputs := tx.Outputs()
+	if output := tx.CollateralReturn(); output != nil {
+		outputs = append(slices.Clip(outputs), output)
+	}
+	ret := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		ret = append(ret, output.Address().String())
+	}
+	slices.Sort(ret)
+	return slices.Compact(ret)
+}
+
+// governanceInfo counts the votes, proposals and governance certificates of
+// tx.
+func governanceInfo(tx ledger.Transaction) GovernanceInfo {
+	ret := GovernanceInfo{
+		Votes:        make(map[string]int),
+		Proposals:    make(map[string]int),
+		Certificates: make(map[string]int),
+	}
+	for voter, votes := range tx.VotingProcedures() {
+		ret.Votes[voterTypeName(voter)] += len(votes)
+	}
+	for _, proposal := range tx.ProposalProcedures() {
+		ret.Proposals[govActionTypeName(proposal.GovAction())]++
+	}
+	for _, cert := range tx.Certificates() {
+		if _, ok := governanceCertificateTypes[cert.Type()]; ok {
+			ret.Certificates[certificateTypeName(cert)]++
+		}
+	}
+	if donation := tx.Donation(); donation != nil && donation.IsUint64() {
+		ret.Donation = donation.Uint64()
+	}
+	return ret
+}
+
+// stakingInfo classifies the certificates of tx and sums its reward
+// withdrawals.
+func stakingInfo(tx ledger.Transaction) StakingInfo {
+	ret := StakingInfo{
+		Certificates: make(map[string]int),
+	}
+	for _, cert := range tx.Certificates() {
+		category, ok := certificateCategories[cert.Type()]
+		if !ok {
+			category = "other"
+		}
+		ret.Certificates[category]++
+	}
+	total := new(big.Int)
+	for _, amount := range tx.Withdrawals() {
+		ret.Withdrawals++
+		if amount != nil {
+			total.Add(total, amount)
+		}
+	}
+	if total.IsUint64() {
+		ret.WithdrawalAmount = total.Uint64()
+	}
+	return ret
+}
+
+// outputValue sums the lovelace and native assets of the outputs.
+func outputValue(outputs []ledger.TransactionOutput) []AssetAmount {
+	lovelace := new(big.Int)
+	assets := make(map[string]*big.Int)
+	for _, output := range outputs {
+		if amount := output.Amount(); amount != nil {
+			lovelace.Add(lovelace, amount)
+		}
+		multiAsset := output.Assets()
+		if multiAsset == nil {
+			continue
+		}
+		for _, policy := range multiAsset.Policies() {
+			for _, name := range multiAsset.Assets(policy) {
+				quantity := multiAsset.Asset(policy, name)
+				if quantity == nil {
+					continue
+				}
+				unit := policy.String() + hex.EncodeToString(name)
+				if total, ok := assets[unit]; ok {
+					total.Add(total, quantity)
+				} else {
+					assets[unit] = new(big.Int).Set(quantity)
+				}
+			}
+		}
+	}
+	ret := []AssetAmount{{Unit: "lovelace", Quantity: lovelace.String()}}
+	for _, unit := range slices.Sorted(maps.Keys(assets)) {
+		ret = append(ret, AssetAmount{Unit: unit, Quantity: assets[unit].String()})
+	}
+	return ret
+}
+
+// decodeTx decodes raw transaction CBOR of any known era.
+func decodeTx(rawBytes []byte) (ledger.Transaction, error) {
+	txType, err := ledger.DetermineTransactionType(rawBytes)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.NewTransactionFromCbor(txType, rawBytes)
+}
@@ -17,9 +17,13 @@ package submit
 import (
 	"bytes"
 	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 )
 
 const (
@@ -186,3 +190,187 @@ func TestParseTxInfo_InvalidCBOR(t *testing.T) {
 		t.Error("expected error for invalid CBOR, got nil")
 	}
 }
+
+func TestParseTxInfo_Summary(t *testing.T) {
+	t.Parallel()
+	addr := append([]byte{0x60}, make([]byte, 28)...)
+	policy := bytes.Repeat([]byte{0xaa}, 28)
+	value := func(lovelace, tokens uint64) []any {
+		return []any{lovelace, map[gocbor.ByteString]map[gocbor.ByteString]uint64{
+			gocbor.NewByteString(policy): {gocbor.NewByteString([]byte("token")): tokens},
+		}}
+	}
+	body := buildMinimalConwayBody()
+	body[1] = []map[uint]any{
+		{0: addr, 1: value(2_000_000, 5)},
+		{0: addr, 1: value(3_000_000, 7)},
+		{0: addr, 1: uint64(1_000_000)},
+	}
+	body[3] = uint64(2_000)
+	body[8] = uint64(1_000)
+	body[18] = [][]any{{bytes.Repeat([]byte{0x01}, 32), uint32(0)}}
+	witnesses := map[uint]any{
+		1: []any{[]any{uint(0), bytes.Repeat([]byte{0x11}, 28)}},
+		3: []any{mustDecodeHex(t, "510101003222253330044a229309b2b2b9a1")},
+		5: [][]any{
+			{uint8(lcommon.RedeemerTagMint), uint32(0), int64(0), []int64{300, 400}},
+			{uint8(lcommon.RedeemerTagSpend), uint32(0), int64(0), []int64{100, 200}},
+		},
+	}
+	txBytes := buildConwayTx(t, body, witnesses)
+
+	info, err := ParseTxInfo(txBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var tx []gocbor.RawMessage
+	if _, err := gocbor.Decode(txBytes, &tx); err != nil {
+		t.Fatalf("decode tx: %v", err)
+	}
+	if want := lcommon.Blake2b256Hash(tx[0]).String(); info.Hash != want {
+		t.Errorf("Hash: want %q, got %q", want, info.Hash)
+	}
+	if info.Era != "Conway" {
+		t.Errorf("Era: want %q, got %q", "Conway", info.Era)
+	}
+	if info.Size != len(txBytes) {
+		t.Errorf("Size: want %d, got %d", len(txBytes), info.Size)
+	}
+	if info.Fee != 100_000 {
+		t.Errorf("Fee: want 100000, got %d", info.Fee)
+	}
+	if info.InputCount != 1 || info.OutputCount != 3 || info.ReferenceInputCount != 1 {
+		t.Errorf("counts: want 1 input, 3 outputs and 1 reference input, got %d, %d and %d",
+			info.InputCount, info.OutputCount, info.ReferenceInputCount)
+	}
+	wantValue := []AssetAmount{
+		{Unit: "lovelace", Quantity: "6000000"},
+		{Unit: hex.EncodeToString(policy) + hex.EncodeToString([]byte("token")), Quantity: "12"},
+	}
+	if !slices.Equal(info.OutputValue, wantValue) {
+		t.Errorf("OutputValue: want %v, got %v", wantValue, info.OutputValue)
+	}
+	if want := (ValidityInterval{InvalidBefore: 1_000, InvalidHereafter: 2_000}); info.ValidityInterval != want {
+		t.Errorf("ValidityInterval: want %+v, got %+v", want, info.ValidityInterval)
+	}
+	if want := []string{"native", "plutus_v1"}; !slices.Equal(info.ScriptTypes, want) {
+		t.Errorf("ScriptTypes: want %v, got %v", want, info.ScriptTypes)
+	}
+	if info.ScriptType != "plutus_v1" {
+		t.Errorf("ScriptType: want %q, got %q", "plutus_v1", info.ScriptType)
+	}
+	wantRedeemers := []RedeemerInfo{
+		{Tag: lcommon.RedeemerTagSpend, Index: 0, ExUnits: lcommon.ExUnits{Memory: 100, Steps: 200}},
+		{Tag: lcommon.RedeemerTagMint, Index: 0, ExUnits: lcommon.ExUnits{Memory: 300, Steps: 400}},
+	}
+	if !slices.Equal(info.Redeemers, wantRedeemers) {
+		t.Errorf("Redeemers: want %+v, got %+v", wantRedeemers, info.Redeemers)
+	}
+}
+
+func TestParseTxInfo_Governance(t *testing.T) {
+	t.Parallel()
+	hash := func(b byte) []byte { return bytes.Repeat([]byte{b}, 28) }
+	rewardAccount := append([]byte{0xe0}, hash(0x99)...)
+	actionId := func(index string) string {
+		return "825820" + strings.Repeat("aa", 32) + index
+	}
+	body := buildMinimalConwayBody()
+	body[4] = []any{
+		// Stake registration, not a governance certificate
+		[]any{uint(0), []any{uint(0), hash(0x01)}},
+		[]any{uint(16), []any{uint(0), hash(0x02)}, uint64(500_000_000), nil},
+		[]any{uint(18), []any{uint(0), hash(0x02)}, nil},
+		[]any{uint(9), []any{uint(0), hash(0x03)}, []any{uint(0), hash(0x02)}},
+		[]any{uint(9), []any{uint(0), hash(0x04)}, []any{uint(2)}},
+	}
+	// A constitutional committee member and a stake pool voting on one action
+	// each, and a DRep voting on two
+	body[19] = gocbor.RawMessage(mustDecodeHex(t, "a3"+
+		"8200581c"+strings.Repeat("11", 28)+"a1"+actionId("00")+"8201f6"+
+		"8202581c"+strings.Repeat("22", 28)+"a2"+actionId("00")+"8200f6"+actionId("01")+"8202f6"+
+		"8204581c"+strings.Repeat("33", 28)+"a1"+actionId("00")+"8201f6"))
+	body[20] = []any{
+		[]any{uint64(100_000_000_000), rewardAccount, []any{uint(6)}, []any{"https://example.com/a.json", bytes.Repeat([]byte{0x55}, 32)}},
+		[]any{uint64(100_000_000_000), rewardAccount, []any{uint(6)}, []any{"https://example.com/b.json", bytes.Repeat([]byte{0x55}, 32)}},
+		[]any{uint64(100_000_000_000), rewardAccount, []any{uint(3), nil}, []any{"https://example.com/c.json", bytes.Repeat([]byte{0x55}, 32)}},
+	}
+	body[22] = uint64(5_000_000)
+
+	info, err := ParseTxInfo(buildConwayTx(t, body, map[uint]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gov := info.Governance
+	if want := map[string]int{"constitutional_committee": 1, "drep": 2, "stake_pool": 1}; !maps.Equal(gov.Votes, want) {
+		t.Errorf("Votes: want %v, got %v", want, gov.Votes)
+	}
+	if want := map[string]int{"info": 2, "no_confidence": 1}; !maps.Equal(gov.Proposals, want) {
+		t.Errorf("Proposals: want %v, got %v", want, gov.Proposals)
+	}
+	want := map[string]int{"drep_registration": 1, "drep_update": 1, "vote_delegation": 2}
+	if !maps.Equal(gov.Certificates, want) {
+		t.Errorf("Certificates: want %v, got %v", want, gov.Certificates)
+	}
+	if gov.Donation != 5_000_000 {
+		t.Errorf("Donation: want 5000000, got %d", gov.Donation)
+	}
+}
+
+func TestParseTxInfo_NoGovernance(t *testing.T) {
+	t.Parallel()
+	info, err := ParseTxInfo(buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gov := info.Governance
+	if len(gov.Votes) != 0 || len(gov.Proposals) != 0 || len(gov.Certificates) != 0 || gov.Donation != 0 {
+		t.Errorf("expected no governance content, got %+v", gov)
+	}
+}
+
+func TestParseTxInfo_Staking(t *testing.T) {
+	t.Parallel()
+	hash := func(b byte) []byte { return bytes.Repeat([]byte{b}, 28) }
+	cred := func(b byte) []any { return []any{uint(0), hash(b)} }
+	body := buildMinimalConwayBody()
+	body[4] = []any{
+		[]any{uint(0), cred(0x01)},
+		[]any{uint(7), cred(0x02), uint64(2_000_000)},
+		[]any{uint(1), cred(0x03)},
+		[]any{uint(8), cred(0x04), uint64(2_000_000)},
+		[]any{uint(2), cred(0x05), hash(0xaa)},
+		[]any{uint(3), hash(0xaa), bytes.Repeat([]byte{0xbb}, 32), uint64(1_000_000), uint64(340_000_000),
+			gocbor.Tag{Number: 30, Content: []any{uint64(1), uint64(100)}},
+			append([]byte{0xe0}, hash(0xaa)...), []any{hash(0xaa)}, []any{}, nil},
+		[]any{uint(4), hash(0xaa), uint64(500)},
+		[]any{uint(11), cred(0x06), hash(0xaa), uint64(2_000_000)},
+		[]any{uint(9), cred(0x07), []any{uint(2)}},
+	}
+	body[5] = map[gocbor.ByteString]uint64{
+		gocbor.NewByteString(append([]byte{0xe0}, hash(0x08)...)): 1_500_000,
+		gocbor.NewByteString(append([]byte{0xe0}, hash(0x09)...)): 2_500_000,
+	}
+
+	info, err := ParseTxInfo(buildConwayTx(t, body, map[uint]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	staking := info.Staking
+	want := map[string]int{
+		"stake_registration":      2,
+		"stake_deregistration":    2,
+		"delegation":              1,
+		"pool_registration":       1,
+		"pool_retirement":         1,
+		"registration_delegation": 1,
+		"governance":              1,
+	}
+	if !maps.Equal(staking.Certificates, want) {
+		t.Errorf("Certificates: want %v, got %v", want, staking.Certificates)
+	}
+	if staking.Withdrawals != 2 || staking.WithdrawalAmount != 4_000_000 {
+		t.Errorf("withdrawals: want 2 totalling 4000000, got %d totalling %d",
+			staking.Withdrawals, staking.WithdrawalAmount)
+	}
+}
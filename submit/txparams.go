@@ -0,0 +1,246 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// minUTxOOverheadBytes is the size added to a serialized output when
+// computing its minimum lovelace from coinsPerUTxOByte, for the transaction
+// input referencing it.
+const minUTxOOverheadBytes = 160
+
+// ParamsViolation is a limit from the protocol parameters that a transaction
+// breaks.
+type ParamsViolation struct {
+	// Check is "collateral_amount", "total_collateral", "collateral_return",
+	// "collateral_assets", "max_collateral_inputs", "min_utxo" or
+	// "max_value_size".
+	Check string `json:"check"`
+	// Ref is the output breaking the limit, "output <index>" or
+	// "collateral_return", for the min_utxo and max_value_size checks.
+	Ref     string `json:"ref,omitempty"`
+	Limit   uint64 `json:"limit"`
+	Actual  uint64 `json:"actual"`
+	Message string `json:"message"`
+}
+
+func (v ParamsViolation) String() string {
+	return v.Message
+}
+
+// ParamsReport is the result of checking a transaction against the protocol
+// parameters with CheckTxParams.
+type ParamsReport struct {
+	Violations []ParamsViolation `json:"violations,omitempty"`
+}
+
+// Valid returns whether the transaction is within all checked limits.
+func (r *ParamsReport) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+func (r *ParamsReport) String() string {
+	problems := make([]string, 0, len(r.Violations))
+	for _, v := range r.Violations {
+		problems = append(problems, v.String())
+	}
+	return strings.Join(problems, "; ")
+}
+
+// CheckTxParams checks the collateral and outputs of a transaction against
+// the protocol parameters. For a transaction with redeemers, the number of
+// collateral inputs is checked against maxCollateralInputs and, when resolve
+// is non-nil, the collateral inputs are resolved to check that their lovelace
+// less the collateral return covers collateralPercentage of the fee, matches
+// any total_collateral field and holds no native assets that are not returned.
+// Every output, and the collateral return, is checked against the minimum
+// lovelace from coinsPerUTxOByte and the maxValueSize limit. Checks whose
+// protocol parameter is not set, such as before Babbage, are skipped.
+func CheckTxParams(txRawBytes []byte, pparams *ProtocolParams, resolve UTxOResolver) (*ParamsReport, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	ret := &ParamsReport{}
+	if hasRedeemers(tx) {
+		if err := checkCollateral(tx, pparams, resolve, ret); err != nil {
+			return nil, err
+		}
+	}
+	for i, output := range tx.Outputs() {
+		checkOutput(output, "output "+strconv.Itoa(i), pparams, ret)
+	}
+	if output := tx.CollateralReturn(); output != nil {
+		checkOutput(output, "collateral_return", pparams, ret)
+	}
+	return ret, nil
+}
+
+func hasRedeemers(tx ledger.Transaction) bool {
+	w := tx.Witnesses()
+	if w == nil || w.Redeemers() == nil {
+		return false
+	}
+	for range w.Redeemers().Iter() {
+		return true
+	}
+	return false
+}
+
+func checkCollateral(tx ledger.Transaction, pparams *ProtocolParams, resolve UTxOResolver, report *ParamsReport) error {
+	collateral := tx.Collateral()
+	if limit := pparams.MaxCollateralInputs; limit != nil && uint64(len(collateral)) > *limit {
+		report.Violations = append(report.Violations, ParamsViolation{
+			Check:   "max_collateral_inputs",
+			Limit:   *limit,
+			Actual:  uint64(len(collateral)),
+			Message: fmt.Sprintf("transaction has %d collateral inputs, more than the maximum of %d", len(collateral), *limit),
+		})
+	}
+	if len(collateral) > 0 && resolve == nil {
+		return nil
+	}
+
+	inputLovelace := new(big.Int)
+	inputAssets := lcommon.NewMultiAsset[lcommon.MultiAssetTypeOutput](nil)
+	if len(collateral) > 0 {
+		utxos, err := resolve(collateral)
+		if err != nil {
+			return err
+		}
+		for _, input := range collateral {
+			output, ok := utxos[input.String()]
+			if !ok {
+				// Missing inputs are reported by FindMissingInputs
+				return nil
+			}
+			inputLovelace.Add(inputLovelace, output.Amount())
+			inputAssets.Add(output.Assets())
+		}
+	}
+
+	balance := new(big.Int).Set(inputLovelace)
+	var returnAssets *lcommon.MultiAsset[lcommon.MultiAssetTypeOutput]
+	if output := tx.CollateralReturn(); output != nil {
+		returnAssets = output.Assets()
+		if output.Amount().Cmp(inputLovelace) > 0 {
+			report.Violations = append(report.Violations, ParamsViolation{
+				Check:  "collateral_return",
+				Limit:  inputLovelace.Uint64(),
+				Actual: output.Amount().Uint64(),
+				Message: fmt.Sprintf(
+					"collateral return of %s lovelace is more than the %s lovelace of the collateral inputs",
+					output.Amount(), inputLovelace,
+				),
+			})
+			return nil
+		}
+		balance.Sub(balance, output.Amount())
+	}
+	if !inputAssets.Compare(returnAssets) {
+		message := "collateral inputs hold native assets that are not in the collateral return output"
+		if tx.CollateralReturn() == nil {
+			message = "collateral inputs hold native assets and there is no collateral return output"
+		}
+		report.Violations = append(report.Violations, ParamsViolation{
+			Check:   "collateral_assets",
+			Message: message,
+		})
+	}
+	if total := tx.TotalCollateral(); total != nil && total.Sign() > 0 && total.Cmp(balance) != 0 {
+		report.Violations = append(report.Violations, ParamsViolation{
+			Check:   "total_collateral",
+			Limit:   total.Uint64(),
+			Actual:  balance.Uint64(),
+			Message: fmt.Sprintf("total collateral field of %s lovelace does not match the collateral balance of %s lovelace", total, balance),
+		})
+	}
+	if pct := pparams.CollateralPercentage; pct != nil {
+		// The ledger requires balance * 100 >= fee * collateralPercentage
+		required := new(big.Int).Mul(tx.Fee(), new(big.Int).SetUint64(*pct))
+		required.Add(required, big.NewInt(99))
+		required.Quo(required, big.NewInt(100))
+		if balance.Cmp(required) < 0 {
+			report.Violations = append(report.Violations, ParamsViolation{
+				Check:  "collateral_amount",
+				Limit:  required.Uint64(),
+				Actual: balance.Uint64(),
+				Message: fmt.Sprintf(
+					"collateral of %s lovelace is less than the required %s lovelace (%d%% of the fee)",
+					balance, required, *pct,
+				),
+			})
+		}
+	}
+	return nil
+}
+
+func checkOutput(output lcommon.TransactionOutput, ref string, pparams *ProtocolParams, report *ParamsReport) {
+	if coinsPerByte := pparams.UtxoCostPerByte; coinsPerByte != nil {
+		size := len(output.Cbor())
+		if size == 0 {
+			if outputCbor, err := cbor.Encode(output); err == nil {
+				size = len(outputCbor)
+			}
+		}
+		// #nosec G115 -- output sizes are bounded by the transaction size
+		minLovelace := (minUTxOOverheadBytes + uint64(size)) * *coinsPerByte
+		if amount := output.Amount(); amount.Cmp(new(big.Int).SetUint64(minLovelace)) < 0 {
+			report.Violations = append(report.Violations, ParamsViolation{
+				Check:   "min_utxo",
+				Ref:     ref,
+				Limit:   minLovelace,
+				Actual:  amount.Uint64(),
+				Message: fmt.Sprintf("%s has %s lovelace, less than the minimum of %d lovelace", ref, amount, minLovelace),
+			})
+		}
+	}
+	if limit := pparams.MaxValueSize; limit != nil {
+		size, err := valueSize(output)
+		if err == nil && size > *limit {
+			report.Violations = append(report.Violations, ParamsViolation{
+				Check:   "max_value_size",
+				Ref:     ref,
+				Limit:   *limit,
+				Actual:  size,
+				Message: fmt.Sprintf("%s value is %d bytes, more than the maximum of %d bytes", ref, size, *limit),
+			})
+		}
+	}
+}
+
+// valueSize returns the serialized size of the value of an output: its
+// lovelace alone, or a [lovelace, multi-asset] pair when it holds native
+// assets.
+func valueSize(output lcommon.TransactionOutput) (uint64, error) {
+	var value any = output.Amount()
+	if assets := output.Assets(); assets != nil && len(assets.Policies()) > 0 {
+		value = []any{output.Amount(), assets}
+	}
+	valueCbor, err := cbor.Encode(value)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(valueCbor)), nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"github.com/blinklabs-io/gouroboros/ledger/mary"
+)
+
+func testParamsLimits() *ProtocolParams {
+	coinsPerByte := uint64(4_310)
+	maxValueSize := uint64(5_000)
+	collateralPercentage := uint64(150)
+	maxCollateralInputs := uint64(3)
+	return &ProtocolParams{
+		UtxoCostPerByte:      &coinsPerByte,
+		MaxValueSize:         &maxValueSize,
+		CollateralPercentage: &collateralPercentage,
+		MaxCollateralInputs:  &maxCollateralInputs,
+	}
+}
+
+// collateralInput returns the "<hash>#0" input with a hash filled with b.
+func collateralInput(b byte) []any {
+	return []any{bytes.Repeat([]byte{b}, 32), uint32(0)}
+}
+
+func TestCheckTxParams(t *testing.T) {
+	addr := append([]byte{0x60}, make([]byte, 28)...)
+	policy := lcommon.NewBlake2b224(bytes.Repeat([]byte{0xaa}, 28))
+	tokens := lcommon.NewMultiAsset(map[lcommon.Blake2b224]map[gocbor.ByteString]*big.Int{
+		policy: {gocbor.NewByteString([]byte("token")): big.NewInt(1)},
+	})
+	// Collateral inputs hold 5 ADA, and the fee of the minimal body is 0.1 ADA,
+	// so the collateral must be at least 0.15 ADA
+	utxos := map[string]ledger.TransactionOutput{
+		strings.Repeat("01", 32) + "#0": babbage.BabbageTransactionOutput{OutputAmount: mary.MaryTransactionOutputValue{Amount: 5_000_000}},
+		strings.Repeat("02", 32) + "#0": babbage.BabbageTransactionOutput{OutputAmount: mary.MaryTransactionOutputValue{Amount: 5_000_000, Assets: &tokens}},
+	}
+	redeemers := map[uint]any{
+		5: [][]any{{uint8(lcommon.RedeemerTagSpend), uint32(0), int64(0), []int64{1_000, 1_000}}},
+	}
+
+	testCases := []struct {
+		name      string
+		body      func(map[uint]any)
+		witnesses map[uint]any
+		pparams   func(*ProtocolParams)
+		want      []string
+	}{
+		{
+			name: "valid",
+			body: func(body map[uint]any) {
+				body[13] = [][]any{collateralInput(0x01)}
+				body[16] = map[uint]any{0: addr, 1: uint64(4_800_000)}
+				body[17] = uint64(200_000)
+			},
+			witnesses: redeemers,
+		},
+		{
+			name: "too little collateral",
+			body: func(body map[uint]any) {
+				body[13] = [][]any{collateralInput(0x01)}
+				body[16] = map[uint]any{0: addr, 1: uint64(4_900_000)}
+			},
+			witnesses: redeemers,
+			want:      []string{"collateral_amount"},
+		},
+		{
+			name:      "no collateral",
+			witnesses: redeemers,
+			want:      []string{"collateral_amount"},
+		},
+		{
+			name: "wrong total collateral",
+			body: func(body map[uint]any) {
+				body[13] = [][]any{collateralInput(0x01)}
+				body[16] = map[uint]any{0: addr, 1: uint64(4_800_000)}
+				body[17] = uint64(300_000)
+			},
+			witnesses: redeemers,
+			want:      []string{"total_collateral"},
+		},
+		{
+			name: "collateral return more than collateral",
+			body: func(body map[uint]any) {
+				body[13] = [][]any{collateralInput(0x01)}
+				body[16] = map[uint]any{0: addr, 1: uint64(6_000_000)}
+			},
+			witnesses: redeemers,
+			want:      []string{"collateral_return"},
+		},
+		{
+			name: "native assets without collateral return",
+			body: func(body map[uint]any) {
+				body[13] = [][]any{collateralInput(0x02)}
+			},
+			witnesses: redeemers,
+			want:      []string{"collateral_assets"},
+		},
+		{
+			name: "too many collateral inputs",
+			body: func(body map[uint]any) {
+				body[13] = [][]any{collateralInput(0x01)}
+			},
+			witnesses: redeemers,
+			pparams: func(pparams *ProtocolParams) {
+				limit := uint64(0)
+				pparams.MaxCollateralInputs = &limit
+			},
+			want: []string{"max_collateral_inputs"},
+		},
+		{
+			name: "no redeemers",
+			body: func(body map[uint]any) {
+				body[16] = map[uint]any{0: addr, 1: uint64(4_800_000)}
+			},
+		},
+		{
+			name: "output below min UTxO",
+			body: func(body map[uint]any) {
+				body[1] = []map[uint]any{
+					{0: addr, 1: uint64(1_000_000_000)},
+					{0: addr, 1: uint64(1_000)},
+				}
+			},
+			want: []string{"min_utxo"},
+		},
+		{
+			name: "value too large",
+			pparams: func(pparams *ProtocolParams) {
+				limit := uint64(4)
+				pparams.MaxValueSize = &limit
+			},
+			want: []string{"max_value_size"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := buildMinimalConwayBody()
+			if tc.body != nil {
+				tc.body(body)
+			}
+			witnesses := tc.witnesses
+			if witnesses == nil {
+				witnesses = map[uint]any{}
+			}
+			pparams := testParamsLimits()
+			if tc.pparams != nil {
+				tc.pparams(pparams)
+			}
+			report, err := CheckTxParams(buildConwayTx(t, body, witnesses), pparams, fixtureResolver(utxos))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got []string
+			for _, v := range report.Violations {
+				got = append(got, v.Check)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("want violations %v, got %v (%s)", tc.want, got, report.String())
+			}
+			if report.Valid() != (len(tc.want) == 0) {
+				t.Errorf("expected Valid() to be %v", len(tc.want) == 0)
+			}
+		})
+	}
+}
+
+func TestCheckTxParams_MinUTxO(t *testing.T) {
+	body := buildMinimalConwayBody()
+	addr := append([]byte{0x60}, make([]byte, 28)...)
+	body[1] = []map[uint]any{{0: addr, 1: uint64(1_000)}}
+	report, err := CheckTxParams(buildConwayTx(t, body, map[uint]any{}), testParamsLimits(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The output {0: addr, 1: 1000} is 37 bytes, so the minimum is
+	// (160 + 37) * 4310 lovelace
+	want := ParamsViolation{
+		Check:   "min_utxo",
+		Ref:     "output 0",
+		Limit:   849_070,
+		Actual:  1_000,
+		Message: "output 0 has 1000 lovelace, less than the minimum of 849070 lovelace",
+	}
+	if len(report.Violations) != 1 || report.Violations[0] != want {
+		t.Fatalf("want %+v, got %+v", want, report.Violations)
+	}
+}
+
+func TestCheckTxParams_UnresolvedCollateral(t *testing.T) {
+	body := buildMinimalConwayBody()
+	body[13] = [][]any{collateralInput(0x03)}
+	witnesses := map[uint]any{
+		5: [][]any{{uint8(lcommon.RedeemerTagSpend), uint32(0), int64(0), []int64{1_000, 1_000}}},
+	}
+	txBytes := buildConwayTx(t, body, witnesses)
+	// Without a resolver, or with collateral missing from the UTxO set, the
+	// collateral balance is not checked
+	for _, resolve := range []UTxOResolver{nil, fixtureResolver(nil)} {
+		report, err := CheckTxParams(txBytes, testParamsLimits(), resolve)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !report.Valid() {
+			t.Errorf("expected no violations, got %s", report.String())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// ResolveUTxOs looks up inputs in the node's current UTxO set over
+// LocalStateQuery. The result is keyed by "<tx hash>#<index>", and inputs
+// that are not in the UTxO set are absent from it.
+func ResolveUTxOs(cfg *Config, inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+	ret := make(map[string]ledger.TransactionOutput, len(inputs))
+	if len(inputs) == 0 {
+		return ret, nil
+	}
+	oConn, err := dialStateQuery(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer oConn.Close()
+
+	result, err := oConn.LocalStateQuery().Client.GetUTxOByTxIn(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failure querying UTxOs: %w", err)
+	}
+	for id, output := range result.Results {
+		ret[fmt.Sprintf("%s#%d", id.Hash.String(), id.Idx)] = output
+	}
+	return ret, nil
+}
+
+// ResolveTxUTxOs returns a UTxOResolver for the checks run on one
+// transaction. The first call resolves the inputs, collateral inputs and
+// reference inputs of the transaction, along with the inputs asked for, with
+// a single call to resolve, and later calls are answered from that result,
+// so that several checks cost one node query. Inputs that were not resolved
+// yet, such as when the transaction cannot be decoded, are passed on to
+// resolve. An error from resolve is returned by every later call that needs
+// it to be queried again.
+func ResolveTxUTxOs(txRawBytes []byte, resolve UTxOResolver) UTxOResolver {
+	var prefetch []ledger.TransactionInput
+	if tx, err := decodeTx(txRawBytes); err == nil {
+		prefetch = slices.Concat(tx.Inputs(), tx.Collateral(), tx.ReferenceInputs())
+	}
+	var mu sync.Mutex
+	utxos := make(map[string]ledger.TransactionOutput)
+	queried := make(map[string]bool)
+	var queryErr error
+	return func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		var query []ledger.TransactionInput
+		seen := make(map[string]bool)
+		for _, input := range slices.Concat(prefetch, inputs) {
+			if key := input.String(); !queried[key] && !seen[key] {
+				seen[key] = true
+				query = append(query, input)
+			}
+		}
+		prefetch = nil
+		if len(query) > 0 {
+			if queryErr != nil {
+				return nil, queryErr
+			}
+			result, err := resolve(query)
+			if err != nil {
+				queryErr = err
+				return nil, err
+			}
+			for _, input := range query {
+				key := input.String()
+				queried[key] = true
+				if output, ok := result[key]; ok {
+					utxos[key] = output
+				}
+			}
+		}
+		ret := make(map[string]ledger.TransactionOutput, len(inputs))
+		for _, input := range inputs {
+			if output, ok := utxos[input.String()]; ok {
+				ret[input.String()] = output
+			}
+		}
+		return ret, nil
+	}
+}
+
+// MissingInput is a transaction input that is not in the UTxO set.
+type MissingInput struct {
+	Input string `json:"input"`
+	// Kind is "input", "collateral" or "reference".
+	Kind string `json:"kind"`
+}
+
+// FindMissingInputs resolves the inputs, collateral inputs and reference
+// inputs of a transaction and returns those that are not in the UTxO set.
+func FindMissingInputs(txRawBytes []byte, resolve UTxOResolver) ([]MissingInput, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	kinds := []struct {
+		kind   string
+		inputs []ledger.TransactionInput
+	}{
+		{"input", tx.Inputs()},
+		{"collateral", tx.Collateral()},
+		{"reference", tx.ReferenceInputs()},
+	}
+	var all []ledger.TransactionInput
+	for _, k := range kinds {
+		all = append(all, k.inputs...)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	utxos, err := resolve(all)
+	if err != nil {
+		return nil, err
+	}
+	var ret []MissingInput
+	for _, k := range kinds {
+		for _, input := range k.inputs {
+			if _, ok := utxos[input.String()]; !ok {
+				ret = append(ret, MissingInput{Input: input.String(), Kind: k.kind})
+			}
+		}
+	}
+	return ret, nil
+}
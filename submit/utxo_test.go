@@ -0,0 +1,150 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+)
+
+func TestFindMissingInputs(t *testing.T) {
+	t.Parallel()
+	body := buildMinimalConwayBody()
+	body[13] = [][]any{{bytes.Repeat([]byte{0xcc}, 32), uint32(0)}} // collateral
+	body[18] = [][]any{{bytes.Repeat([]byte{0xaa}, 32), uint32(1)}} // reference inputs
+	txBytes := buildConwayTx(t, body, map[uint]any{})
+
+	spent := strings.Repeat("00", 32) + "#0"
+	collateral := strings.Repeat("cc", 32) + "#0"
+	reference := strings.Repeat("aa", 32) + "#1"
+
+	var queried int
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		queried = len(inputs)
+		return map[string]ledger.TransactionOutput{
+			collateral: babbage.BabbageTransactionOutput{},
+		}, nil
+	}
+	missing, err := FindMissingInputs(txBytes, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if queried != 3 {
+		t.Errorf("expected 3 inputs to be queried, got %d", queried)
+	}
+	want := []MissingInput{
+		{Input: spent, Kind: "input"},
+		{Input: reference, Kind: "reference"},
+	}
+	if len(missing) != len(want) {
+		t.Fatalf("want %v, got %v", want, missing)
+	}
+	for i := range want {
+		if missing[i] != want[i] {
+			t.Errorf("want %v, got %v", want[i], missing[i])
+		}
+	}
+}
+
+func TestFindMissingInputs_AllPresent(t *testing.T) {
+	t.Parallel()
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	resolve := func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		ret := make(map[string]ledger.TransactionOutput)
+		for _, input := range inputs {
+			ret[input.String()] = babbage.BabbageTransactionOutput{}
+		}
+		return ret, nil
+	}
+	missing, err := FindMissingInputs(txBytes, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected no missing inputs, got %v", missing)
+	}
+}
+
+func TestFindMissingInputs_ResolveError(t *testing.T) {
+	t.Parallel()
+	txBytes := buildConwayTx(t, buildMinimalConwayBody(), map[uint]any{})
+	_, err := FindMissingInputs(txBytes, func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		return nil, errors.New("node unavailable")
+	})
+	if err == nil {
+		t.Error("expected resolver error to be returned")
+	}
+}
+
+func TestResolveTxUTxOs(t *testing.T) {
+	t.Parallel()
+	body := buildMinimalConwayBody()
+	body[13] = [][]any{{bytes.Repeat([]byte{0xcc}, 32), uint32(0)}} // collateral
+	body[18] = [][]any{{bytes.Repeat([]byte{0xaa}, 32), uint32(1)}} // reference inputs
+	txBytes := buildConwayTx(t, body, map[uint]any{})
+	tx, err := decodeTx(txBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	collateral := strings.Repeat("cc", 32) + "#0"
+
+	var calls, queried int
+	resolve := ResolveTxUTxOs(txBytes, func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		calls++
+		queried += len(inputs)
+		return map[string]ledger.TransactionOutput{
+			collateral: babbage.BabbageTransactionOutput{},
+		}, nil
+	})
+	// Every check asks for a different subset of the inputs
+	for _, inputs := range [][]ledger.TransactionInput{
+		tx.Collateral(),
+		tx.Inputs(),
+		append(tx.Inputs(), tx.ReferenceInputs()...),
+	} {
+		utxos, err := resolve(inputs)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, input := range inputs {
+			if _, ok := utxos[input.String()]; ok != (input.String() == collateral) {
+				t.Errorf("unexpected result for %s: %v", input.String(), utxos)
+			}
+		}
+	}
+	if calls != 1 || queried != 3 {
+		t.Errorf("expected 1 call for 3 inputs, got %d calls for %d inputs", calls, queried)
+	}
+
+	// Errors are not retried
+	calls = 0
+	resolve = ResolveTxUTxOs(txBytes, func([]ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+		calls++
+		return nil, errors.New("node unavailable")
+	})
+	for range 2 {
+		if _, err := resolve(tx.Inputs()); err == nil {
+			t.Error("expected error")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidityIntervalError is returned by CheckValidityInterval for a
+// transaction whose validity interval does not include the next slot.
+type ValidityIntervalError struct {
+	// Expired is set when invalid_hereafter has passed, and unset when
+	// invalid_before has not been reached yet.
+	Expired bool
+	// Slot is the invalid_hereafter or invalid_before slot.
+	Slot uint64
+	// Delta is how long ago the transaction expired, or how long until it
+	// becomes valid.
+	Delta time.Duration
+}
+
+func (e *ValidityIntervalError) Error() string {
+	seconds := int64(e.Delta / time.Second)
+	if e.Expired {
+		return fmt.Sprintf("transaction expired %d seconds ago (slot %d)", seconds, e.Slot)
+	}
+	return fmt.Sprintf("transaction is not valid for another %d seconds (slot %d)", seconds, e.Slot)
+}
+
+// CheckValidityInterval checks the invalid_before and invalid_hereafter slots
+// of a transaction against the slot after tipSlot, which is the slot the node
+// mempool validates against. It returns a *ValidityIntervalError if the
+// transaction is outside its validity interval.
+func CheckValidityInterval(txRawBytes []byte, clock *SlotClock, tipSlot uint64) error {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return err
+	}
+	slot := tipSlot + 1
+	slotTime, err := clock.SlotToTime(slot)
+	if err != nil {
+		return err
+	}
+	if ttl := tx.TTL(); ttl != 0 && ttl <= slot {
+		ttlTime, err := clock.SlotToTime(ttl)
+		if err != nil {
+			return err
+		}
+		return &ValidityIntervalError{Expired: true, Slot: ttl, Delta: slotTime.Sub(ttlTime)}
+	}
+	if start := tx.ValidityIntervalStart(); start > slot {
+		startTime, err := clock.SlotToTime(start)
+		if err != nil {
+			return err
+		}
+		return &ValidityIntervalError{Slot: start, Delta: startTime.Sub(slotTime)}
+	}
+	return nil
+}
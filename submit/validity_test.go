@@ -0,0 +1,108 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheckValidityInterval(t *testing.T) {
+	t.Parallel()
+	clock := mainnetSlotClock(t)
+	const tipSlot = 12_345_677
+	tests := []struct {
+		name          string
+		ttl           uint64
+		validityStart uint64
+		wantErr       string
+	}{
+		{"no interval", 0, 0, ""},
+		{"within interval", tipSlot + 600, tipSlot - 600, ""},
+		{"expires next slot", tipSlot + 1, 0, "transaction expired 0 seconds ago (slot 12345678)"},
+		{"expired", tipSlot - 311, 0, "transaction expired 312 seconds ago (slot 12345366)"},
+		{"valid next slot", 0, tipSlot + 1, ""},
+		{"not yet valid", 0, tipSlot + 46, "transaction is not valid for another 45 seconds (slot 12345723)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			body := buildMinimalConwayBody()
+			if tt.ttl != 0 {
+				body[3] = tt.ttl
+			}
+			if tt.validityStart != 0 {
+				body[8] = tt.validityStart
+			}
+			err := CheckValidityInterval(buildConwayTx(t, body, map[uint]any{}), clock, tipSlot)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var validityErr *ValidityIntervalError
+			if !errors.As(err, &validityErr) {
+				t.Fatalf("expected ValidityIntervalError, got %v", err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("want %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestCheckValidityInterval_InvalidTx(t *testing.T) {
+	t.Parallel()
+	err := CheckValidityInterval([]byte("not-valid-cbor"), mainnetSlotClock(t), 0)
+	var validityErr *ValidityIntervalError
+	if err == nil || errors.As(err, &validityErr) {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+}
+
+func TestSlotClockFromShelleyGenesis(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "shelley-genesis.json")
+	genesis := `{"systemStart": "2024-06-01T00:00:00Z", "epochLength": 500, "slotLength": 0.1}`
+	if err := os.WriteFile(path, []byte(genesis), 0o600); err != nil {
+		t.Fatalf("failed to write genesis: %s", err)
+	}
+	clock, err := SlotClockFromShelleyGenesis(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := clock.SlotToTime(1234)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := time.Date(2024, time.June, 1, 0, 2, 3, 400_000_000, time.UTC); !got.Equal(want) {
+		t.Errorf("want %s, got %s", want, got)
+	}
+	epoch, err := clock.SlotToEpoch(1234)
+	if err != nil || epoch != 2 {
+		t.Errorf("want epoch 2, got %d (%v)", epoch, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"systemStart": "2024-06-01T00:00:00Z"}`), 0o600); err != nil {
+		t.Fatalf("failed to write genesis: %s", err)
+	}
+	if _, err := SlotClockFromShelleyGenesis(path); err == nil {
+		t.Error("expected error for genesis without slot length, got nil")
+	}
+}
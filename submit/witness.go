@@ -0,0 +1,229 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// MissingWitness is a key hash that a transaction needs a vkey witness for,
+// but has none.
+type MissingWitness struct {
+	KeyHash string `json:"keyHash"`
+	// Source is what requires the witness: "required_signer", "input",
+	// "collateral", "certificate", "withdrawal" or "voter".
+	Source string `json:"source"`
+	// Ref identifies the input, certificate index, reward address or voter
+	// requiring the witness.
+	Ref string `json:"ref,omitempty"`
+}
+
+func (m MissingWitness) String() string {
+	if m.Ref == "" {
+		return fmt.Sprintf("missing witness for %s %s", strings.ReplaceAll(m.Source, "_", " "), m.KeyHash)
+	}
+	return fmt.Sprintf("missing witness for %s %s (%s)", strings.ReplaceAll(m.Source, "_", " "), m.KeyHash, m.Ref)
+}
+
+// WitnessReport is the result of checking the vkey witnesses of a
+// transaction with VerifyWitnesses.
+type WitnessReport struct {
+	// InvalidSignatures are the key hashes of vkey witnesses whose signature
+	// does not verify against the transaction body hash.
+	InvalidSignatures []string         `json:"invalidSignatures,omitempty"`
+	MissingWitnesses  []MissingWitness `json:"missingWitnesses,omitempty"`
+}
+
+// Valid returns whether all signatures verified and no witnesses are missing.
+func (r *WitnessReport) Valid() bool {
+	return len(r.InvalidSignatures) == 0 && len(r.MissingWitnesses) == 0
+}
+
+func (r *WitnessReport) String() string {
+	problems := make([]string, 0, len(r.InvalidSignatures)+len(r.MissingWitnesses))
+	for _, keyHash := range r.InvalidSignatures {
+		problems = append(problems, "invalid signature from key "+keyHash)
+	}
+	for _, m := range r.MissingWitnesses {
+		problems = append(problems, m.String())
+	}
+	return strings.Join(problems, "; ")
+}
+
+// VerifyWitnesses verifies the Ed25519 signature of every vkey witness of a
+// transaction against its body hash, and checks that the required signers
+// and the key hash credentials of its certificates, withdrawals and votes
+// all have a vkey witness. When resolve is non-nil, the spent and collateral
+// inputs are resolved to check their key hash addresses too. Byron inputs,
+// which are witnessed by bootstrap witnesses, and script credentials are not
+// checked.
+func VerifyWitnesses(txRawBytes []byte, resolve UTxOResolver) (*WitnessReport, error) {
+	tx, err := decodeTx(txRawBytes)
+	if err != nil {
+		return nil, err
+	}
+	ret := &WitnessReport{}
+	txHash := tx.Hash()
+	witnessed := make(map[lcommon.Blake2b224]bool)
+	if w := tx.Witnesses(); w != nil {
+		for _, vw := range w.Vkey() {
+			keyHash := lcommon.Blake2b224Hash(vw.Vkey)
+			witnessed[keyHash] = true
+			if err := lcommon.VerifyVKeySignature(vw.Vkey, vw.Signature, txHash[:]); err != nil {
+				ret.InvalidSignatures = append(ret.InvalidSignatures, keyHash.String())
+			}
+		}
+	}
+	require := func(keyHash lcommon.Blake2b224, source, ref string) {
+		if !witnessed[keyHash] {
+			ret.MissingWitnesses = append(ret.MissingWitnesses, MissingWitness{
+				KeyHash: keyHash.String(),
+				Source:  source,
+				Ref:     ref,
+			})
+		}
+	}
+
+	for _, signer := range tx.RequiredSigners() {
+		require(signer, "required_signer", "")
+	}
+	if inputs, collateral := tx.Inputs(), tx.Collateral(); resolve != nil && len(inputs)+len(collateral) > 0 {
+		utxos, err := resolve(slices.Concat(inputs, collateral))
+		if err != nil {
+			return nil, err
+		}
+		for _, kind := range []struct {
+			source string
+			inputs []ledger.TransactionInput
+		}{{"input", inputs}, {"collateral", collateral}} {
+			for _, input := range kind.inputs {
+				output, ok := utxos[input.String()]
+				if !ok {
+					continue
+				}
+				addr := output.Address()
+				if addr.Type() == lcommon.AddressTypeByron {
+					continue
+				}
+				if payload, ok := addr.PayloadPayload().(lcommon.AddressPayloadKeyHash); ok {
+					require(payload.Hash, kind.source, input.String())
+				}
+			}
+		}
+	}
+	for i, cert := range tx.Certificates() {
+		for _, keyHash := range certWitnessKeyHashes(cert) {
+			require(keyHash, "certificate", strconv.Itoa(i))
+		}
+	}
+	var withdrawals []MissingWitness
+	for addr := range tx.Withdrawals() {
+		if addr == nil {
+			continue
+		}
+		if payload, ok := addr.StakingPayload().(lcommon.AddressPayloadKeyHash); ok && !witnessed[payload.Hash] {
+			withdrawals = append(withdrawals, MissingWitness{
+				KeyHash: payload.Hash.String(),
+				Source:  "withdrawal",
+				Ref:     addr.String(),
+			})
+		}
+	}
+	var voters []MissingWitness
+	for voter := range tx.VotingProcedures() {
+		if voter == nil {
+			continue
+		}
+		switch voter.Type {
+		case lcommon.VoterTypeConstitutionalCommitteeHotKeyHash,
+			lcommon.VoterTypeDRepKeyHash,
+			lcommon.VoterTypeStakingPoolKeyHash:
+			if keyHash := lcommon.Blake2b224(voter.Hash); !witnessed[keyHash] {
+				voters = append(voters, MissingWitness{
+					KeyHash: keyHash.String(),
+					Source:  "voter",
+					Ref:     voter.String(),
+				})
+			}
+		}
+	}
+	// Withdrawals and votes are maps, so sort them for a stable result
+	sortMissingWitnesses(withdrawals)
+	sortMissingWitnesses(voters)
+	ret.MissingWitnesses = append(ret.MissingWitnesses, withdrawals...)
+	ret.MissingWitnesses = append(ret.MissingWitnesses, voters...)
+	return ret, nil
+}
+
+func sortMissingWitnesses(m []MissingWitness) {
+	slices.SortFunc(m, func(a, b MissingWitness) int {
+		return strings.Compare(a.Ref, b.Ref)
+	})
+}
+
+// certWitnessKeyHashes returns the key hashes that must witness a
+// certificate. Stake registrations without a deposit need no witness, and
+// genesis delegation and MIR certificates are not checked.
+func certWitnessKeyHashes(cert lcommon.Certificate) []lcommon.Blake2b224 {
+	var ret []lcommon.Blake2b224
+	for _, cred := range certWitnessCredentials(cert) {
+		if cred.CredType == lcommon.CredentialTypeAddrKeyHash {
+			ret = append(ret, cred.Credential)
+		}
+	}
+	return ret
+}
+
+// certWitnessCredentials returns the credentials that must witness a
+// certificate, with the pool keys of pool certificates as key hash
+// credentials.
+func certWitnessCredentials(cert lcommon.Certificate) []lcommon.Credential {
+	keyHash := func(hash lcommon.Blake2b224) lcommon.Credential {
+		return lcommon.Credential{CredType: lcommon.CredentialTypeAddrKeyHash, Credential: lcommon.CredentialHash(hash)}
+	}
+	switch c := cert.(type) {
+	case *lcommon.StakeRegistrationCertificate:
+		return nil
+	case *lcommon.PoolRegistrationCertificate:
+		ret := []lcommon.Credential{keyHash(lcommon.Blake2b224(c.Operator))}
+		for _, owner := range c.PoolOwners {
+			ret = append(ret, keyHash(lcommon.Blake2b224(owner)))
+		}
+		return ret
+	case *lcommon.PoolRetirementCertificate:
+		return []lcommon.Credential{keyHash(lcommon.Blake2b224(c.PoolKeyHash))}
+	case *lcommon.RegistrationDrepCertificate:
+		return []lcommon.Credential{c.DrepCredential}
+	case *lcommon.DeregistrationDrepCertificate:
+		return []lcommon.Credential{c.DrepCredential}
+	case *lcommon.UpdateDrepCertificate:
+		return []lcommon.Credential{c.DrepCredential}
+	case *lcommon.AuthCommitteeHotCertificate:
+		return []lcommon.Credential{c.ColdCredential}
+	case *lcommon.ResignCommitteeColdCertificate:
+		return []lcommon.Credential{c.ColdCredential}
+	default:
+		if cred := certStakeCredential(cert); cred != nil {
+			return []lcommon.Credential{*cred}
+		}
+		return nil
+	}
+}
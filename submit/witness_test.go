@@ -0,0 +1,147 @@
+// Copyright 2026 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package submit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"slices"
+	"strings"
+	"testing"
+
+	gocbor "github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/ledger/babbage"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// buildSignedTx encodes body and signs its hash with key, corrupting the
+// signature when tamper is set.
+func buildSignedTx(t *testing.T, body map[uint]any, key ed25519.PrivateKey, tamper bool) []byte {
+	t.Helper()
+	bodyBytes, err := gocbor.Encode(body)
+	if err != nil {
+		t.Fatalf("encode body: %v", err)
+	}
+	bodyHash := lcommon.Blake2b256Hash(bodyBytes)
+	sig := ed25519.Sign(key, bodyHash[:])
+	if tamper {
+		sig[0] ^= 0xff
+	}
+	witnesses := map[uint]any{
+		0: [][]any{{[]byte(key.Public().(ed25519.PublicKey)), sig}},
+	}
+	return buildConwayTx(t, gocbor.RawMessage(bodyBytes), witnesses)
+}
+
+func TestVerifyWitnesses(t *testing.T) {
+	t.Parallel()
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x01}, ed25519.SeedSize))
+	signer := lcommon.Blake2b224Hash(key.Public().(ed25519.PublicKey))
+	hash := func(b byte) []byte { return bytes.Repeat([]byte{b}, 28) }
+
+	body := buildMinimalConwayBody()
+	// Stake registration without a deposit needs no witness, deregistration does
+	body[4] = []any{
+		[]any{uint(0), []any{uint(0), hash(0xe1)}},
+		[]any{uint(1), []any{uint(0), hash(0xd1)}},
+	}
+	body[5] = map[any]uint64{gocbor.NewByteString(append([]byte{0xe1}, hash(0xc1)...)): 1_000}
+	body[14] = [][]byte{signer.Bytes(), hash(0xb1)}
+
+	report, err := VerifyWitnesses(buildSignedTx(t, body, key, false), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(report.InvalidSignatures) != 0 {
+		t.Errorf("unexpected invalid signatures: %v", report.InvalidSignatures)
+	}
+	var got []string
+	for _, m := range report.MissingWitnesses {
+		got = append(got, m.Source+":"+m.KeyHash)
+	}
+	want := []string{
+		"required_signer:" + strings.Repeat("b1", 28),
+		"certificate:" + strings.Repeat("d1", 28),
+		"withdrawal:" + strings.Repeat("c1", 28),
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("want missing witnesses %v, got %v", want, got)
+	}
+	if report.Valid() {
+		t.Error("expected report not to be valid")
+	}
+	if !strings.Contains(report.String(), "missing witness for required signer "+strings.Repeat("b1", 28)) {
+		t.Errorf("unexpected report message %q", report.String())
+	}
+}
+
+func TestVerifyWitnesses_Inputs(t *testing.T) {
+	t.Parallel()
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x02}, ed25519.SeedSize))
+	signer := lcommon.Blake2b224Hash(key.Public().(ed25519.PublicKey))
+	txBytes := buildSignedTx(t, buildMinimalConwayBody(), key, false)
+
+	resolveTo := func(keyHash []byte) UTxOResolver {
+		addr, err := lcommon.NewAddressFromBytes(append([]byte{0x61}, keyHash...))
+		if err != nil {
+			t.Fatalf("failed to build address: %s", err)
+		}
+		return func(inputs []ledger.TransactionInput) (map[string]ledger.TransactionOutput, error) {
+			return map[string]ledger.TransactionOutput{
+				inputs[0].String(): babbage.BabbageTransactionOutput{OutputAddress: addr},
+			}, nil
+		}
+	}
+
+	report, err := VerifyWitnesses(txBytes, resolveTo(signer.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !report.Valid() {
+		t.Errorf("expected input spent by the signer to be witnessed, got %s", report.String())
+	}
+
+	report, err = VerifyWitnesses(txBytes, resolveTo(bytes.Repeat([]byte{0xf1}, 28)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(report.MissingWitnesses) != 1 || report.MissingWitnesses[0].Source != "input" ||
+		report.MissingWitnesses[0].Ref != strings.Repeat("00", 32)+"#0" {
+		t.Errorf("expected missing input witness, got %+v", report.MissingWitnesses)
+	}
+}
+
+func TestVerifyWitnesses_InvalidSignature(t *testing.T) {
+	t.Parallel()
+	key := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{0x03}, ed25519.SeedSize))
+	signer := lcommon.Blake2b224Hash(key.Public().(ed25519.PublicKey))
+
+	report, err := VerifyWitnesses(buildSignedTx(t, buildMinimalConwayBody(), key, true), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !slices.Equal(report.InvalidSignatures, []string{signer.String()}) {
+		t.Errorf("expected invalid signature from %s, got %v", signer, report.InvalidSignatures)
+	}
+
+	report, err = VerifyWitnesses(buildSignedTx(t, buildMinimalConwayBody(), key, false), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !report.Valid() {
+		t.Errorf("expected valid signature, got %s", report.String())
+	}
+}